@@ -132,3 +132,14 @@ func (c *Client) GetMatchDetails(ctx context.Context, matchID string) (MatchDeta
 	}
 	return details, nil
 }
+
+// CancelMatch cancels a confirmed match. Clubs can refuse a cancellation,
+// for instance close to the start, so callers must handle an error.
+func (c *Client) CancelMatch(ctx context.Context, matchID string) error {
+	path := "/matches/" + url.PathEscape(matchID) + "/cancel"
+	req, err := c.newAPIRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doStatus(req)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -106,53 +107,6 @@ func (c *Client) GetAvailability(ctx context.Context, tenantID string, start, en
 	return availability, nil
 }
 
-func (c *Client) Geocode(ctx context.Context, query string) (float64, float64, error) {
-	endpoint := "https://nominatim.openstreetmap.org/search"
-	q := url.Values{}
-	q.Set("format", "json")
-	q.Set("limit", "1")
-	q.Set("q", query)
-	urlStr := endpoint + "?" + q.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
-	if err != nil {
-		return 0, 0, err
-	}
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return 0, 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, 0, fmt.Errorf("geocode failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
-	}
-
-	var results []struct {
-		Lat string `json:"lat"`
-		Lon string `json:"lon"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		return 0, 0, err
-	}
-	if len(results) == 0 {
-		return 0, 0, fmt.Errorf("no results for %q", query)
-	}
-	lat, err := strconv.ParseFloat(results[0].Lat, 64)
-	if err != nil {
-		return 0, 0, err
-	}
-	lon, err := strconv.ParseFloat(results[0].Lon, 64)
-	if err != nil {
-		return 0, 0, err
-	}
-	return lat, lon, nil
-}
-
 func (c *Client) newPublicRequest(ctx context.Context, method, path string, query url.Values) (*http.Request, error) {
 	return c.newRequest(ctx, c.PublicBaseURL, method, path, query, nil, false)
 }
@@ -200,8 +154,7 @@ func (c *Client) doJSON(req *http.Request, dest any) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		return newHTTPError(resp)
 	}
 
 	if dest == nil {
@@ -225,8 +178,29 @@ func (c *Client) doStatus(req *http.Request) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		return newHTTPError(resp)
 	}
 	return nil
 }
+
+// HTTPError is returned for responses outside the 2xx range.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func newHTTPError(resp *http.Response) *HTTPError {
+	body, _ := io.ReadAll(resp.Body)
+	return &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: strings.TrimSpace(string(body))}
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("request failed: %s: %s", e.Status, e.Body)
+}
+
+// IsNotFound reports whether err is a 404 from the API.
+func IsNotFound(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
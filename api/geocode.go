@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNominatimURL       = "https://nominatim.openstreetmap.org"
+	defaultNominatimUserAgent = "padel-cli/1.0 (command-line Playtomic client)"
+	nominatimInterval         = time.Second
+)
+
+// Geocoder turns a place name into coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, query string) (Coordinate, error)
+}
+
+// NominatimGeocoder queries OpenStreetMap's Nominatim. It follows the usage
+// policy (https://operations.osmfoundation.org/policies/nominatim/): it
+// identifies the application in the User-Agent, passes the contact email when
+// one is set, and sends at most one request per second.
+type NominatimGeocoder struct {
+	HTTP      *http.Client
+	BaseURL   string
+	UserAgent string
+	Email     string
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func NewNominatimGeocoder(baseURL, email string) *NominatimGeocoder {
+	if baseURL == "" {
+		baseURL = defaultNominatimURL
+	}
+	return &NominatimGeocoder{
+		HTTP:      &http.Client{Timeout: 10 * time.Second},
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		UserAgent: defaultNominatimUserAgent,
+		Email:     email,
+	}
+}
+
+func (g *NominatimGeocoder) Geocode(ctx context.Context, query string) (Coordinate, error) {
+	if err := g.wait(ctx); err != nil {
+		return Coordinate{}, err
+	}
+
+	q := url.Values{}
+	q.Set("format", "json")
+	q.Set("limit", "1")
+	q.Set("q", query)
+	if g.Email != "" {
+		q.Set("email", g.Email)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.BaseURL+"/search?"+q.Encode(), nil)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	req.Header.Set("User-Agent", g.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.HTTP.Do(req)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return Coordinate{}, fmt.Errorf("geocode failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Coordinate{}, err
+	}
+	if len(results) == 0 {
+		return Coordinate{}, fmt.Errorf("no results for %q", query)
+	}
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	return Coordinate{Lat: lat, Lon: lon}, nil
+}
+
+// wait blocks until a second has passed since the previous request.
+func (g *NominatimGeocoder) wait(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if delay := nominatimInterval - time.Since(g.last); !g.last.IsZero() && delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	g.last = time.Now()
+	return nil
+}
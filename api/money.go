@@ -0,0 +1,154 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Money is a price as quoted by Playtomic, e.g. "43.68 EUR".
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// MoneyFormat resolves what a price string leaves ambiguous, usually from the
+// user's locale. The zero value guesses.
+type MoneyFormat struct {
+	// Decimal is the decimal separator, "." or ",". When set, a lone other
+	// separator followed by three digits groups thousands ("1.234" with ","
+	// is 1234); otherwise such a separator is read as the decimal point.
+	Decimal string
+	// Krona is the currency "kr" stands for: SEK, NOK or DKK. When empty,
+	// prices in "kr" get no currency.
+	Krona string
+}
+
+var currencySymbols = map[string]string{
+	"€":   "EUR",
+	"£":   "GBP",
+	"$":   "USD",
+	"us$": "USD",
+}
+
+// kronaSymbols are shared by the Swedish, Norwegian and Danish krona.
+var kronaSymbols = map[string]bool{
+	"kr":  true,
+	"kr.": true,
+}
+
+// ParseMoney parses price strings such as "43.68 EUR", "EUR 43.68", "€43,68"
+// or "US$ 20". Currency is left empty when the input does not name one.
+func ParseMoney(input string) (Money, error) {
+	return ParseMoneyFormat(input, MoneyFormat{})
+}
+
+// ParseMoneyFormat is ParseMoney with format settling ambiguous separators
+// and "kr" (e.g. "1.250 kr" for a Norwegian user is 1250 NOK).
+func ParseMoneyFormat(input string, format MoneyFormat) (Money, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return Money{}, fmt.Errorf("empty price")
+	}
+
+	var number strings.Builder
+	var unit strings.Builder
+	for _, r := range input {
+		switch {
+		case unicode.IsDigit(r), r == '-':
+			number.WriteRune(r)
+		case r == '.' || r == ',':
+			// A dot right after letters belongs to the symbol ("kr.").
+			if number.Len() == 0 && unit.Len() > 0 {
+				unit.WriteRune(r)
+			} else {
+				number.WriteRune(r)
+			}
+		case unicode.IsSpace(r), r == ' ':
+		default:
+			unit.WriteRune(r)
+		}
+	}
+
+	amount, err := parseAmount(strings.TrimRight(number.String(), ".,"), format.Decimal)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid price %q", input)
+	}
+	currency, err := parseCurrency(unit.String(), format.Krona)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid price %q: %w", input, err)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func parseCurrency(unit, krona string) (string, error) {
+	if unit == "" {
+		return "", nil
+	}
+	if kronaSymbols[strings.ToLower(unit)] {
+		return krona, nil
+	}
+	if code, ok := currencySymbols[strings.ToLower(unit)]; ok {
+		return code, nil
+	}
+	code := strings.ToUpper(unit)
+	if len(code) != 3 {
+		return "", fmt.Errorf("unknown currency %q", unit)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("unknown currency %q", unit)
+		}
+	}
+	return code, nil
+}
+
+// parseAmount accepts both "1,234.56" and "1.234,56". With both kinds of
+// separator, or one repeated, the last one decides. A single separator is
+// the decimal point, unless it is followed by exactly three digits and
+// decimalHint names the other separator, in which case it groups thousands.
+func parseAmount(input, decimalHint string) (float64, error) {
+	if input == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+	lastDot := strings.LastIndex(input, ".")
+	lastComma := strings.LastIndex(input, ",")
+	decimal := ""
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastDot > lastComma {
+			decimal = "."
+		} else {
+			decimal = ","
+		}
+	case lastDot >= 0:
+		decimal = "."
+	case lastComma >= 0:
+		decimal = ","
+	}
+
+	if decimal != "" {
+		other := ","
+		if decimal == "," {
+			other = "."
+		}
+		digitsAfter := len(input) - strings.LastIndex(input, decimal) - 1
+		single := !strings.Contains(input, other)
+		if strings.Count(input, decimal) > 1 || (single && digitsAfter == 3 && decimalHint == other) {
+			decimal = ""
+		}
+	}
+
+	var cleaned strings.Builder
+	for _, r := range input {
+		switch {
+		case decimal != "" && string(r) == decimal:
+			cleaned.WriteRune('.')
+		case r == '.' || r == ',':
+		default:
+			cleaned.WriteRune(r)
+		}
+	}
+	return strconv.ParseFloat(cleaned.String(), 64)
+}
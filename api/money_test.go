@@ -0,0 +1,56 @@
+package api
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input  string
+		format MoneyFormat
+		want   Money
+	}{
+		{input: "43.68 EUR", want: Money{Amount: 43.68, Currency: "EUR"}},
+		{input: "EUR 43.68", want: Money{Amount: 43.68, Currency: "EUR"}},
+		{input: "€43,68", want: Money{Amount: 43.68, Currency: "EUR"}},
+		{input: "43.680 EUR", want: Money{Amount: 43.68, Currency: "EUR"}},
+		{input: "1.234,56 €", want: Money{Amount: 1234.56, Currency: "EUR"}},
+		{input: "1,234.56 GBP", want: Money{Amount: 1234.56, Currency: "GBP"}},
+		{input: "1.234.567 EUR", want: Money{Amount: 1234567, Currency: "EUR"}},
+		{input: "US$ 20", want: Money{Amount: 20, Currency: "USD"}},
+		{input: "$20.50", want: Money{Amount: 20.5, Currency: "USD"}},
+		{input: "42", want: Money{Amount: 42}},
+		{input: "-5,5 EUR", want: Money{Amount: -5.5, Currency: "EUR"}},
+
+		// A lone separator before three digits groups thousands only when
+		// the locale uses the other one as its decimal point.
+		{input: "1.250 EUR", format: MoneyFormat{Decimal: ","}, want: Money{Amount: 1250, Currency: "EUR"}},
+		{input: "1,250 EUR", format: MoneyFormat{Decimal: "."}, want: Money{Amount: 1250, Currency: "EUR"}},
+		{input: "1.250 EUR", format: MoneyFormat{Decimal: "."}, want: Money{Amount: 1.25, Currency: "EUR"}},
+		{input: "43.68 EUR", format: MoneyFormat{Decimal: ","}, want: Money{Amount: 43.68, Currency: "EUR"}},
+
+		// "kr" is only a currency when the format says which krona.
+		{input: "250 kr", want: Money{Amount: 250}},
+		{input: "250 kr", format: MoneyFormat{Krona: "NOK"}, want: Money{Amount: 250, Currency: "NOK"}},
+		{input: "kr. 250", format: MoneyFormat{Krona: "DKK"}, want: Money{Amount: 250, Currency: "DKK"}},
+		{input: "250 kr.", format: MoneyFormat{Krona: "SEK"}, want: Money{Amount: 250, Currency: "SEK"}},
+		{input: "250 SEK", format: MoneyFormat{Krona: "NOK"}, want: Money{Amount: 250, Currency: "SEK"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoneyFormat(tt.input, tt.format)
+		if err != nil {
+			t.Errorf("ParseMoneyFormat(%q, %+v) error: %v", tt.input, tt.format, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoneyFormat(%q, %+v) = %+v, want %+v", tt.input, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	for _, input := range []string{"", "EUR", "12 euros", "12 E$"} {
+		if got, err := ParseMoney(input); err == nil {
+			t.Errorf("ParseMoney(%q) = %+v, want error", input, got)
+		}
+	}
+}
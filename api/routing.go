@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const earthRadiusKm = 6371.0
+
+// Route is the travel time and road distance between two points.
+type Route struct {
+	Duration   time.Duration `json:"duration"`
+	DistanceKm float64       `json:"distance_km"`
+}
+
+// Router estimates travel between two coordinates.
+type Router interface {
+	Route(ctx context.Context, from, to Coordinate) (Route, error)
+}
+
+// OSRMRouter asks an OSRM server (https://project-osrm.org) for routes. Any
+// service that speaks the OSRM route API works, such as a local osrm-backend.
+type OSRMRouter struct {
+	HTTP    *http.Client
+	BaseURL string
+	Profile string
+}
+
+func NewOSRMRouter(baseURL, profile string) *OSRMRouter {
+	if profile == "" {
+		profile = "driving"
+	}
+	return &OSRMRouter{
+		HTTP:    &http.Client{Timeout: 10 * time.Second},
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Profile: profile,
+	}
+}
+
+func (r *OSRMRouter) Route(ctx context.Context, from, to Coordinate) (Route, error) {
+	path := fmt.Sprintf("/route/v1/%s/%f,%f;%f,%f", url.PathEscape(r.Profile), from.Lon, from.Lat, to.Lon, to.Lat)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.BaseURL+path+"?overview=false", nil)
+	if err != nil {
+		return Route{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.HTTP.Do(req)
+	if err != nil {
+		return Route{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return Route{}, fmt.Errorf("route failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Code   string `json:"code"`
+		Routes []struct {
+			Duration float64 `json:"duration"`
+			Distance float64 `json:"distance"`
+		} `json:"routes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return Route{}, err
+	}
+	if payload.Code != "Ok" || len(payload.Routes) == 0 {
+		return Route{}, fmt.Errorf("no route found (%s)", payload.Code)
+	}
+	return Route{
+		Duration:   time.Duration(payload.Routes[0].Duration * float64(time.Second)),
+		DistanceKm: payload.Routes[0].Distance / 1000,
+	}, nil
+}
+
+// HaversineKm returns the great-circle distance between two coordinates.
+func HaversineKm(from, to Coordinate) float64 {
+	lat1 := from.Lat * math.Pi / 180
+	lat2 := to.Lat * math.Pi / 180
+	dLat := (to.Lat - from.Lat) * math.Pi / 180
+	dLon := (to.Lon - from.Lon) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
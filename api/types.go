@@ -1,10 +1,27 @@
 package api
 
 type Tenant struct {
-	TenantID   string     `json:"tenant_id"`
-	TenantName string     `json:"tenant_name"`
-	Address    Address    `json:"address"`
-	Resources  []Resource `json:"resources"`
+	TenantID        string                  `json:"tenant_id"`
+	TenantName      string                  `json:"tenant_name"`
+	Address         Address                 `json:"address"`
+	Resources       []Resource              `json:"resources"`
+	OpeningHours    map[string]OpeningHours `json:"opening_hours"`
+	BookingSettings BookingSettings         `json:"booking_settings"`
+}
+
+// OpeningHours holds one day's hours, keyed in Tenant.OpeningHours by
+// upper-case weekday ("MONDAY") or "HOLIDAYS". Times are local "HH:MM:SS".
+type OpeningHours struct {
+	OpeningTime string `json:"opening_time"`
+	ClosingTime string `json:"closing_time"`
+}
+
+// BookingSettings is the club's booking policy. Not every club exposes it;
+// zero values mean unknown.
+type BookingSettings struct {
+	MaxAdvanceDays            int    `json:"booking_ahead_limit_days"`
+	BookingOpensAt            string `json:"booking_opening_time"`
+	CancellationWindowMinutes int    `json:"cancelation_limit_minutes"`
 }
 
 type Address struct {
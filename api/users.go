@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// UserProfile is the public profile of a Playtomic user.
+type UserProfile struct {
+	UserID   string `json:"user_id"`
+	FullName string `json:"full_name"`
+	Gender   string `json:"gender"`
+}
+
+// PlayerLevel is a user's level in one sport.
+type PlayerLevel struct {
+	UserID          string  `json:"user_id"`
+	SportID         string  `json:"sport_id"`
+	LevelValue      float64 `json:"level_value"`
+	LevelConfidence float64 `json:"level_confidence"`
+}
+
+func (c *Client) GetUser(ctx context.Context, userID string) (UserProfile, error) {
+	path := "/users/" + url.PathEscape(userID)
+	req, err := c.newAPIRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return UserProfile{}, err
+	}
+
+	var profile UserProfile
+	if err := c.doJSON(req, &profile); err != nil {
+		return UserProfile{}, err
+	}
+	return profile, nil
+}
+
+// GetPadelLevel returns the user's padel level. ok is false when the user
+// has no level yet.
+func (c *Client) GetPadelLevel(ctx context.Context, userID string) (PlayerLevel, bool, error) {
+	q := url.Values{}
+	q.Set("user_id", userID)
+	q.Set("sport_id", "PADEL")
+
+	req, err := c.newAPIRequest(ctx, "GET", "/levels", q)
+	if err != nil {
+		return PlayerLevel{}, false, err
+	}
+
+	var levels []PlayerLevel
+	if err := c.doJSON(req, &levels); err != nil {
+		return PlayerLevel{}, false, err
+	}
+	for _, level := range levels {
+		if strings.EqualFold(level.SportID, "PADEL") || level.SportID == "" {
+			return level, true, nil
+		}
+	}
+	return PlayerLevel{}, false, nil
+}
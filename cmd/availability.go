@@ -6,24 +6,40 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	"padel-cli/api"
+	"padel-cli/storage"
 
 	"github.com/spf13/cobra"
 )
 
 type AvailabilitySlot struct {
-	Court         string `json:"court"`
-	Time          string `json:"time"`
-	StartUTC      string `json:"start_utc"`
-	VenueTimezone string `json:"venue_timezone"`
-	Duration      int    `json:"duration"`
-	Available     bool   `json:"available"`
-	Price         string `json:"price"`
-	Indoor        bool   `json:"indoor"`
-	ResourceID    string `json:"resource_id,omitempty"`
+	Court          string  `json:"court"`
+	Time           string  `json:"time"`
+	StartUTC       string  `json:"start_utc"`
+	VenueTimezone  string  `json:"venue_timezone"`
+	Duration       int     `json:"duration"`
+	Available      bool    `json:"available"`
+	Price          string  `json:"price"`
+	PriceAmount    float64 `json:"price_amount,omitempty"`
+	Currency       string  `json:"currency,omitempty"`
+	Players        int     `json:"players,omitempty"`
+	PricePerPlayer float64 `json:"price_per_player,omitempty"`
+	PricePerHour   float64 `json:"price_per_hour,omitempty"`
+	Indoor         bool    `json:"indoor"`
+	Size           string  `json:"size,omitempty"`
+	Feature        string  `json:"feature,omitempty"`
+	ResourceID     string  `json:"resource_id,omitempty"`
+}
+
+// SlotRecord is a single slot flattened with its club and date, used for
+// line-oriented output such as ndjson and csv.
+type SlotRecord struct {
+	Date     string `json:"date"`
+	ClubID   string `json:"club_id"`
+	ClubName string `json:"club_name"`
+	AvailabilitySlot
 }
 
 type AvailabilityOutput struct {
@@ -31,18 +47,22 @@ type AvailabilityOutput struct {
 	ClubName string             `json:"club_name"`
 	Date     string             `json:"date"`
 	Slots    []AvailabilitySlot `json:"slots"`
+	Reason   string             `json:"reason,omitempty"`
 }
 
 func availabilityCmd() *cobra.Command {
 	var clubID string
 	var venueAlias string
 	var date string
-	var showOutdoor bool
-	var showAll bool
+	var days int
+	var perCourt bool
+	var marks bool
+	var filter slotFilter
+	var players int
 
 	cmd := &cobra.Command{
 		Use:   "availability",
-		Short: "Show availability for a club on a date",
+		Short: "Show availability for a club on a date or across several days",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if clubID != "" && venueAlias != "" {
 				return fmt.Errorf("use either --club-id or --venue, not both")
@@ -50,14 +70,21 @@ func availabilityCmd() *cobra.Command {
 			if clubID == "" && venueAlias == "" {
 				return fmt.Errorf("--club-id or --venue is required")
 			}
+			if days < 0 || days > maxDateSpan {
+				return fmt.Errorf("--days must be between 1 and %d", maxDateSpan)
+			}
 			if date == "" {
-				return fmt.Errorf("--date is required")
+				if days == 0 {
+					return fmt.Errorf("--date is required unless --days is set")
+				}
+				date = "today"
 			}
-			if showOutdoor && showAll {
-				return fmt.Errorf("use either --outdoor or --all, not both")
+			if err := filter.validate(); err != nil {
+				return err
 			}
 
 			venueTimezone := ""
+			var cachedPolicy *storage.VenuePolicy
 			if venueAlias != "" {
 				venue, err := lookupVenue(venueAlias)
 				if err != nil {
@@ -65,6 +92,8 @@ func availabilityCmd() *cobra.Command {
 				}
 				clubID = venue.ID
 				venueTimezone = venue.TimeZone
+				filter.Rules = venue.CourtRules
+				cachedPolicy = venue.Policy
 			}
 
 			ctx := context.Background()
@@ -86,19 +115,15 @@ func availabilityCmd() *cobra.Command {
 			venueTimezone = normalizeVenueTimezone(venueTimezone)
 			location := venueLocation(venueTimezone)
 
-			target, err := parseDateInputInLocation(date, location)
+			targets, err := parseDateRangeInLocation(date, location)
 			if err != nil {
 				return err
 			}
-
-			startLocal := time.Date(target.Year(), target.Month(), target.Day(), 0, 0, 0, 0, location)
-			endLocal := time.Date(target.Year(), target.Month(), target.Day(), 23, 59, 59, 0, location)
-			startUTC := startLocal.UTC()
-			endUTC := endLocal.UTC()
-
-			availability, err := client.GetAvailability(ctx, clubID, startUTC, endUTC)
-			if err != nil {
-				return err
+			if days > 0 {
+				if len(targets) != 1 {
+					return fmt.Errorf("use either a date range or --days, not both")
+				}
+				targets = daysBetween(targets[0], targets[0].AddDate(0, 0, days-1))
 			}
 
 			// Build resource map with indoor info
@@ -107,131 +132,186 @@ func availabilityCmd() *cobra.Command {
 				resourceInfo[resource.ResourceID] = resource
 			}
 
-			targetDate := target.Format("2006-01-02")
-			slots := flattenAvailabilityWithResources(availability, resourceInfo, targetDate, venueTimezone, showOutdoor, showAll)
+			policy := resolvePolicy(tenant, cachedPolicy)
 
-			output := AvailabilityOutput{
-				ClubID:   clubID,
-				ClubName: tenant.TenantName,
-				Date:     targetDate,
-				Slots:    slots,
-			}
+			outputs := make([]AvailabilityOutput, 0, len(targets))
+			for idx, target := range targets {
+				availability, err := fetchDayAvailability(ctx, clubID, target, location, filter)
+				if err != nil {
+					return err
+				}
+
+				targetDate := target.Format("2006-01-02")
+				slots := flattenAvailabilityWithResources(availability, resourceInfo, targetDate, venueTimezone, filter)
+				slots = applySlotPricing(slots, players)
+				output := AvailabilityOutput{
+					ClubID:   clubID,
+					ClubName: tenant.TenantName,
+					Date:     targetDate,
+					Slots:    slots,
+				}
+				if len(slots) == 0 {
+					output.Reason = explainNoSlots(policy, target, time.Now(), countSlots(availability))
+				}
+				outputs = append(outputs, output)
 
-			if outputJSON {
-				return writeJSON(output)
+				if idx < len(targets)-1 {
+					time.Sleep(rateLimitDelay)
+				}
 			}
 
-			return renderAvailability(output)
+			if len(outputs) == 1 && !perCourt {
+				return render(availabilitySpec(outputs[0]))
+			}
+			return render(availabilityGridSpec(buildAvailabilityGrid(outputs, perCourt), marks))
 		},
 	}
 
 	cmd.Flags().StringVar(&clubID, "club-id", "", "Club (tenant) ID")
 	cmd.Flags().StringVar(&venueAlias, "venue", "", "Saved venue alias")
-	cmd.Flags().StringVar(&date, "date", "", "Date (YYYY-MM-DD)")
-	cmd.Flags().BoolVar(&showOutdoor, "outdoor", false, "Show only outdoor courts")
-	cmd.Flags().BoolVar(&showAll, "all", false, "Show all courts (indoor and outdoor)")
+	cmd.Flags().StringVar(&date, "date", "", "Date or date range (YYYY-MM-DD, today, sat, next saturday, +3d, this week, A..B)")
+	cmd.Flags().IntVar(&days, "days", 0, "Show a grid of this many days starting at --date (default today)")
+	cmd.Flags().BoolVar(&perCourt, "per-court", false, "Show the grid per court instead of counting free courts")
+	cmd.Flags().BoolVar(&marks, "marks", false, "Show \u2713/\u2717 in grid cells instead of free court counts")
+	cmd.Flags().BoolVar(&filter.ShowOutdoor, "outdoor", false, "Show only outdoor courts")
+	cmd.Flags().BoolVar(&filter.ShowAll, "all", false, "Show all courts (indoor and outdoor)")
+	addSlotFilterFlags(cmd, &filter)
+	addTimeWindowFlags(cmd, &filter)
+	cmd.Flags().IntVar(&players, "players", defaultPlayers, "Number of players to split the price between")
 	return cmd
 }
 
+// fetchDayAvailability loads a club's availability on day, adding the next
+// morning up to the end of the last time window when one crosses midnight.
+func fetchDayAvailability(ctx context.Context, clubID string, day time.Time, location *time.Location, filter slotFilter) ([]api.AvailabilityResource, error) {
+	startLocal := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, location)
+	endLocal := time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, location)
+	availability, err := client.GetAvailability(ctx, clubID, startLocal.UTC(), endLocal.UTC())
+	if err != nil || !filter.crossesMidnight() {
+		return availability, err
+	}
+
+	time.Sleep(rateLimitDelay)
+	nextStart := startLocal.AddDate(0, 0, 1)
+	nextEnd := nextStart.Add(time.Duration(filter.lastWindowEnd()-minutesPerDay) * time.Minute)
+	morning, err := client.GetAvailability(ctx, clubID, nextStart.UTC(), nextEnd.UTC())
+	if err != nil {
+		return nil, err
+	}
+	return append(availability, morning...), nil
+}
+
 func flattenAvailability(resources []api.AvailabilityResource, resourceNames map[string]string, targetDate, venueTimezone string) []AvailabilitySlot {
 	// Legacy function for backward compatibility - shows all courts
 	resourceInfo := map[string]api.Resource{}
 	for id, name := range resourceNames {
 		resourceInfo[id] = api.Resource{ResourceID: id, Name: name}
 	}
-	return flattenAvailabilityWithResources(resources, resourceInfo, targetDate, venueTimezone, false, true)
+	return flattenAvailabilityWithResources(resources, resourceInfo, targetDate, venueTimezone, slotFilter{ShowAll: true})
 }
 
-func flattenAvailabilityWithResources(resources []api.AvailabilityResource, resourceInfo map[string]api.Resource, targetDate, venueTimezone string, showOutdoor, showAll bool) []AvailabilitySlot {
-	slots := []AvailabilitySlot{}
-	for _, resource := range resources {
-		resInfo, hasInfo := resourceInfo[resource.ResourceID]
-		court := resource.ResourceID
-		if hasInfo && resInfo.Name != "" {
-			court = resInfo.Name
-		}
+func flattenAvailabilityWithResources(resources []api.AvailabilityResource, resourceInfo map[string]api.Resource, targetDate, venueTimezone string, filter slotFilter) []AvailabilitySlot {
+	return filterAvailabilityWithResources(resources, resourceInfo, targetDate, venueTimezone, filter)
+}
 
-		// Determine if indoor
-		isIndoor := true // default to indoor if unknown
-		if hasInfo {
-			isIndoor = resInfo.IsIndoor()
-		}
+func availabilitySpec(output AvailabilityOutput) renderSpec {
+	return renderSpec{
+		Data: output,
+		Records: func() []any {
+			return slotRecordValues(availabilityRecords(output))
+		},
+		Table: func() tableData {
+			return slotTable(availabilityRecords(output))
+		},
+		Text: func() error {
+			return renderAvailability(output)
+		},
+		Compact: func() error {
+			return renderCompactAvailability(output)
+		},
+	}
+}
 
-		// Filter by indoor/outdoor
-		if !showAll {
-			if showOutdoor && isIndoor {
-				continue // skip indoor when showing outdoor only
-			}
-			if !showOutdoor && !isIndoor {
-				continue // skip outdoor when showing indoor only (default)
-			}
-		}
+func availabilityRecords(output AvailabilityOutput) []SlotRecord {
+	records := make([]SlotRecord, 0, len(output.Slots))
+	for _, slot := range output.Slots {
+		records = append(records, SlotRecord{
+			Date:             output.Date,
+			ClubID:           output.ClubID,
+			ClubName:         output.ClubName,
+			AvailabilitySlot: slot,
+		})
+	}
+	return records
+}
 
-		for _, slot := range resource.Slots {
-			resourceDate := resource.StartDate
-			if strings.Contains(resourceDate, "T") && len(resourceDate) >= 10 {
-				resourceDate = resourceDate[:10]
-			}
-			localDate, localTime, startUTC, ok := apiUTCDateTimeToLocal(resourceDate, slot.StartTime, venueTimezone)
-			if ok && targetDate != "" && localDate != targetDate {
-				continue
-			}
-			if localTime == "" {
-				localTime = timeLabel(slot.StartTime)
-			}
-			slots = append(slots, AvailabilitySlot{
-				Court:         court,
-				Time:          localTime,
-				StartUTC:      startUTC,
-				VenueTimezone: normalizeVenueTimezone(venueTimezone),
-				Duration:      slot.Duration,
-				Available:     true,
-				Price:         slot.Price,
-				Indoor:        isIndoor,
-				ResourceID:    resource.ResourceID,
-			})
-		}
+func slotRecordValues(records []SlotRecord) []any {
+	values := make([]any, 0, len(records))
+	for _, record := range records {
+		values = append(values, record)
 	}
+	return values
+}
 
-	sort.Slice(slots, func(i, j int) bool {
-		if slots[i].Court == slots[j].Court {
-			return slots[i].Time < slots[j].Time
+func slotTable(records []SlotRecord) tableData {
+	table := tableData{Headers: []string{"DATE", "CLUB", "COURT", "TIME", "DURATION", "PRICE", "PER_PLAYER", "PER_HOUR", "CURRENCY", "INDOOR", "SIZE", "FEATURE"}}
+	for _, record := range records {
+		indoor := "no"
+		if record.Indoor {
+			indoor = "yes"
 		}
-		return slots[i].Court < slots[j].Court
-	})
-	return slots
+		table.Rows = append(table.Rows, []string{
+			record.Date,
+			record.ClubName,
+			record.Court,
+			record.Time,
+			fmt.Sprintf("%d", record.Duration),
+			record.Price,
+			plainAmount(record.PricePerPlayer),
+			plainAmount(record.PricePerHour),
+			record.Currency,
+			indoor,
+			record.Size,
+			record.Feature,
+		})
+	}
+	return table
 }
 
 func renderAvailability(output AvailabilityOutput) error {
+	fmt.Printf("%s (%s)\nDate: %s\n", output.ClubName, output.ClubID, output.Date)
 	if len(output.Slots) == 0 {
-		fmt.Printf("%s (%s)\nDate: %s\nNo available slots.\n", output.ClubName, output.ClubID, output.Date)
+		fmt.Println(noSlotsMessage(output.Reason))
 		return nil
 	}
 
-	fmt.Printf("%s (%s)\nDate: %s\n", output.ClubName, output.ClubID, output.Date)
+	table := tableData{Headers: []string{"COURT", "TIME", "DURATION", "SIZE", "FEATURE", "PRICE", "PER PLAYER", "PER HOUR"}}
+	for _, slot := range output.Slots {
+		table.Rows = append(table.Rows, []string{slot.Court, slot.Time, fmt.Sprintf("%dm", slot.Duration), slot.Size, slot.Feature, formatPriceLabel(slot.Price, slot.VenueTimezone), formatPerPlayer(slot), formatPerHour(slot)})
+	}
+	return writeTable(os.Stdout, table)
+}
 
-	if outputCompact {
-		byCourt := map[string][]AvailabilitySlot{}
-		for _, slot := range output.Slots {
-			byCourt[slot.Court] = append(byCourt[slot.Court], slot)
-		}
-		parts := []string{}
-		for court, slots := range byCourt {
-			times := make([]string, 0, len(slots))
-			for _, slot := range slots {
-				times = append(times, slot.Time)
-			}
-			parts = append(parts, fmt.Sprintf("%s: %s", court, strings.Join(uniqueSortedTimes(times), " ")))
-		}
-		sort.Strings(parts)
-		fmt.Println(strings.Join(parts, " | "))
+func renderCompactAvailability(output AvailabilityOutput) error {
+	fmt.Printf("%s (%s)\nDate: %s\n", output.ClubName, output.ClubID, output.Date)
+	if len(output.Slots) == 0 {
+		fmt.Println(noSlotsMessage(output.Reason))
 		return nil
 	}
 
-	writer := tabwriter.NewWriter(os.Stdout, 2, 2, 2, ' ', 0)
-	fmt.Fprintln(writer, "COURT\tTIME\tDURATION\tPRICE")
+	byCourt := map[string][]AvailabilitySlot{}
 	for _, slot := range output.Slots {
-		fmt.Fprintf(writer, "%s\t%s\t%dm\t%s\n", slot.Court, slot.Time, slot.Duration, slot.Price)
+		byCourt[slot.Court] = append(byCourt[slot.Court], slot)
+	}
+	parts := []string{}
+	for court, slots := range byCourt {
+		times := make([]string, 0, len(slots))
+		for _, slot := range slots {
+			times = append(times, slot.Time)
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", court, strings.Join(uniqueSortedTimes(times), " ")))
 	}
-	return writer.Flush()
+	sort.Strings(parts)
+	fmt.Println(strings.Join(parts, " | "))
+	return nil
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -16,17 +17,19 @@ import (
 func bookCmd() *cobra.Command {
 	var venueAlias string
 	var date string
-	var timeValue string
 	var duration int
+	var filter slotFilter
 	var court string
 	var players int
 	var paymentMethod string
+	var groups groupOptions
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "book",
 		Short: "Book a court",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if venueAlias == "" || date == "" || timeValue == "" {
+			if venueAlias == "" || date == "" || filter.TimeRange == "" {
 				return fmt.Errorf("--venue, --date, and --time are required")
 			}
 			if duration <= 0 {
@@ -35,6 +38,14 @@ func bookCmd() *cobra.Command {
 			if players <= 0 {
 				players = 4
 			}
+			filter.ShowAll = true
+			filter.Duration = duration
+			if err := filter.validate(); err != nil {
+				return err
+			}
+			if err := groups.validate(); err != nil {
+				return err
+			}
 
 			creds, err := storage.LoadCredentials()
 			if err != nil {
@@ -52,11 +63,7 @@ func bookCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-
-			requestedMinutes, err := parseClock(timeValue)
-			if err != nil {
-				return err
-			}
+			filter.Rules = venue.CourtRules
 
 			ctx := context.Background()
 			tenant, err := client.GetTenant(ctx, venue.ID)
@@ -76,94 +83,87 @@ func bookCmd() *cobra.Command {
 				return err
 			}
 
-			startLocal := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, location)
-			endLocal := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 23, 59, 59, 0, location)
-			startDay := startLocal.UTC()
-			endDay := endLocal.UTC()
-
-			availability, err := client.GetAvailability(ctx, venue.ID, startDay, endDay)
-			if err != nil {
-				return err
-			}
-
-			resourceNames := map[string]string{}
-			for _, resource := range tenant.Resources {
-				resourceNames[resource.ResourceID] = resource.Name
-			}
-
-			targetDateStr := targetDate.Format("2006-01-02")
-			slot, resourceID, resourceName, err := selectSlot(availability, resourceNames, targetDateStr, venueTimezone, requestedMinutes, duration, court)
+			availability, err := fetchDayAvailability(ctx, venue.ID, targetDate, location, groups.fetchFilter(filter))
 			if err != nil {
 				return err
 			}
 
-			startTime := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), requestedMinutes/60, requestedMinutes%60, 0, 0, location)
-			startUTC := startTime.UTC()
-			startUTCString := startUTC.Format(time.RFC3339)
-
-			intent := api.PaymentIntentRequest{
-				AllowedPaymentMethodTypes: []string{"OFFER", "CASH", "MERCHANT_WALLET", "DIRECT", "SWISH", "IDEAL", "BANCONTACT", "PAYTRAIL", "CREDIT_CARD", "QUICK_PAY"},
-				UserID:                    creds.UserID,
-				Cart: api.PaymentIntentCart{
-					RequestedItem: api.PaymentIntentItem{
-						CartItemType:      "CUSTOMER_MATCH",
-						CartItemVoucherID: nil,
-						CartItemData: api.PaymentIntentItemData{
-							SupportsSplitPayment: true,
-							NumberOfPlayers:      players,
-							TenantID:             venue.ID,
-							ResourceID:           resourceID,
-							Start:                startUTC.Format("2006-01-02T15:04:05"),
-							Duration:             duration,
-							MatchRegistrations: []api.MatchRegistration{
-								{UserID: creds.UserID, PayNow: true},
-							},
-						},
-					},
-				},
-			}
-
-			intentResp, err := client.CreatePaymentIntent(ctx, intent)
+			resources, err := client.GetResources(ctx, venue.ID)
 			if err != nil {
-				return err
+				// Fall back to tenant resources if GetResources fails
+				resources = tenant.Resources
 			}
-
-			availableMethods := extractPaymentMethods(intentResp.AvailablePaymentMethods)
-			selected, err := choosePaymentMethod(availableMethods, paymentMethod)
-			if err != nil {
-				return err
+			resourceInfo := map[string]api.Resource{}
+			for _, resource := range resources {
+				resourceInfo[resource.ResourceID] = resource
 			}
 
-			if selected != "" {
-				if err := client.UpdatePaymentIntent(ctx, intentResp.PaymentIntentID, api.PaymentIntentUpdateRequest{SelectedPaymentMethod: selected}); err != nil {
+			targetDateStr := targetDate.Format("2006-01-02")
+			var blocks []bookingBlock
+			if groups.enabled() {
+				fetchFilter := groups.fetchFilter(filter)
+				if court != "" {
+					// An explicit --court wins over the venue's court rules.
+					fetchFilter.Rules = nil
+				}
+				slots := filterAvailabilityWithResources(availability, resourceInfo, targetDateStr, venueTimezone, fetchFilter)
+				if court != "" {
+					slots = slotsOnCourt(slots, court)
+				}
+				slots = applySlotPricing(slots, players)
+				candidates := groups.groups(slots, filter)
+				if len(candidates) == 0 {
+					return fmt.Errorf("no matching slot combination for %s", courtOrAny(court))
+				}
+				for _, slot := range candidates[0].Slots {
+					block, err := slotBookingBlock(slot, location)
+					if err != nil {
+						return err
+					}
+					blocks = append(blocks, block)
+				}
+			} else {
+				match, err := selectSlot(availability, resourceInfo, targetDateStr, venueTimezone, filter, court)
+				if err != nil {
 					return err
 				}
-			}
-
-			confirmResp, err := client.ConfirmPaymentIntent(ctx, intentResp.PaymentIntentID)
-			if err != nil {
+				blocks = []bookingBlock{{
+					ResourceID:   match.ResourceID,
+					ResourceName: match.ResourceName,
+					Start:        time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), match.Minutes/60, match.Minutes%60, 0, 0, location),
+					Duration:     duration,
+					Price:        match.Slot.Price,
+				}}
+			}
+
+			bookings := make([]storage.Booking, 0, len(blocks))
+			for _, block := range blocks {
+				price, err := parsePrice(block.Price, venueTimezone)
+				if err != nil && block.Price != "" {
+					fmt.Fprintf(os.Stderr, "warning: price %q of %s not understood; it is saved without a price\n", block.Price, block.ResourceName)
+				}
+				bookings = append(bookings, storage.Booking{
+					VenueAlias:    venue.Alias,
+					VenueName:     tenant.TenantName,
+					VenueID:       venue.ID,
+					Court:         block.ResourceName,
+					Date:          block.Start.Format("2006-01-02"),
+					Time:          block.Start.Format("15:04"),
+					StartUTC:      block.Start.UTC().Format(time.RFC3339),
+					VenueTimezone: venueTimezone,
+					Duration:      block.Duration,
+					Price:         price.Amount,
+					Currency:      price.Currency,
+					Source:        "cli_booked",
+				})
+			}
+			if err := checkBudget(bookings, force); err != nil {
 				return err
 			}
 
-			bookingID := extractBookingID(confirmResp)
-			if bookingID == "" {
-				bookingID = newBookingID()
-			}
-
-			booking := storage.Booking{
-				ID:            bookingID,
-				VenueAlias:    venue.Alias,
-				VenueName:     tenant.TenantName,
-				VenueID:       venue.ID,
-				Court:         resourceName,
-				Date:          targetDateStr,
-				Time:          timeValue,
-				StartUTC:      startUTCString,
-				VenueTimezone: venueTimezone,
-				Duration:      duration,
-				Price:         parsePriceAmount(slot.Price),
-				BookedAt:      time.Now().UTC().Format(time.RFC3339),
-				Source:        "cli_booked",
+			kept, bookErr := bookBlocks(ctx, client, creds.UserID, venue.ID, blocks, players, paymentMethod)
+			if len(kept) == 0 {
+				return bookErr
 			}
 
 			db, err := storage.OpenBookingsDB()
@@ -172,87 +172,271 @@ func bookCmd() *cobra.Command {
 			}
 			defer db.Close()
 
-			_, err = storage.AddBookingIfNotExists(db, booking)
-			if err != nil {
-				return err
-			}
+			booked := make([]storage.Booking, 0, len(kept))
+			for _, item := range kept {
+				block := blocks[item.Block]
+				booking := bookings[item.Block]
+				booking.ID = item.ID
+				if booking.ID == "" {
+					booking.ID = newBookingID()
+				}
+				booking.BookedAt = time.Now().UTC().Format(time.RFC3339)
+				if _, err := storage.AddBookingIfNotExists(db, booking); err != nil {
+					return err
+				}
+				booked = append(booked, booking)
 
-			fmt.Printf("Booked: %s %s %s\n", tenant.TenantName, timeValue, targetDate.Format("Mon 2 Jan"))
-			priceLabel := slot.Price
-			if priceLabel == "" {
-				priceLabel = formatEUR(booking.Price)
+				fmt.Printf("Booked: %s %s %s\n", tenant.TenantName, booking.Time, block.Start.Format("Mon 2 Jan"))
+				fmt.Printf("%s | %dmin | %s\n", block.ResourceName, block.Duration, formatMoney(bookingMoney(booking)))
+				fmt.Printf("Booking ID: %s\n", booking.ID)
+			}
+			if bookErr != nil {
+				reportPartialBooking(booked, kept)
+				return bookErr
 			}
-			fmt.Printf("%s | %dmin | %s\n", resourceName, duration, priceLabel)
-			fmt.Printf("Booking ID: %s\n", bookingID)
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&venueAlias, "venue", "", "Saved venue alias")
-	cmd.Flags().StringVar(&date, "date", "", "Date (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&timeValue, "time", "", "Time (HH:MM)")
+	cmd.Flags().StringVar(&date, "date", "", "Date (YYYY-MM-DD, today, sat, next saturday, +3d)")
+	cmd.Flags().StringVar(&filter.TimeRange, "time", "", "Time (HH:MM), or windows (HH:MM-HH:MM,...) to book the earliest free slot")
+	addTimeModeFlag(cmd, &filter)
 	cmd.Flags().IntVar(&duration, "duration", 90, "Duration in minutes")
+	cmd.Flags().StringVar(&filter.Size, "size", "", "Only book courts of this size (double|single)")
+	cmd.Flags().StringVar(&filter.Feature, "feature", "", "Only book courts with this feature (e.g. panoramic)")
 	cmd.Flags().StringVar(&court, "court", "", "Court name")
 	cmd.Flags().IntVar(&players, "players", 4, "Number of players")
 	cmd.Flags().StringVar(&paymentMethod, "payment-method", "", "Payment method code")
+	addGroupFlags(cmd, &groups)
+	cmd.Flags().BoolVar(&force, "force", false, "Book even if it goes over the budget")
 	return cmd
 }
 
-func selectSlot(resources []api.AvailabilityResource, resourceNames map[string]string, targetDate, venueTimezone string, targetMinutes int, duration int, court string) (api.Slot, string, string, error) {
+// bookingBlock is one court and start time to book.
+type bookingBlock struct {
+	ResourceID   string
+	ResourceName string
+	Start        time.Time
+	Duration     int
+	Price        string
+}
+
+func slotBookingBlock(slot AvailabilitySlot, location *time.Location) (bookingBlock, error) {
+	start, err := time.Parse(time.RFC3339, slot.StartUTC)
+	if err != nil {
+		return bookingBlock{}, fmt.Errorf("slot %s on %s has no start time", slot.Time, slot.Court)
+	}
+	return bookingBlock{
+		ResourceID:   slot.ResourceID,
+		ResourceName: slot.Court,
+		Start:        start.In(location),
+		Duration:     slot.Duration,
+		Price:        slot.Price,
+	}, nil
+}
+
+func slotsOnCourt(slots []AvailabilitySlot, court string) []AvailabilitySlot {
+	filtered := []AvailabilitySlot{}
+	for _, slot := range slots {
+		if strings.EqualFold(slot.Court, strings.TrimSpace(court)) {
+			filtered = append(filtered, slot)
+		}
+	}
+	return filtered
+}
+
+// blockBooker is the part of the Playtomic client that books and cancels
+// matches.
+type blockBooker interface {
+	CreatePaymentIntent(ctx context.Context, payload api.PaymentIntentRequest) (api.PaymentIntentResponse, error)
+	UpdatePaymentIntent(ctx context.Context, paymentIntentID string, payload api.PaymentIntentUpdateRequest) error
+	ConfirmPaymentIntent(ctx context.Context, paymentIntentID string) (map[string]any, error)
+	CancelMatch(ctx context.Context, matchID string) error
+}
+
+// bookedBlock is a block that is booked on Playtomic. ID is empty when
+// Playtomic did not report one.
+type bookedBlock struct {
+	Block int
+	ID    string
+}
+
+// bookBlocks books every block or none. Payment intents are created for all
+// blocks before any is confirmed, so a block that was taken in the meantime
+// fails early with nothing booked. When a later confirmation still fails,
+// the blocks confirmed so far are cancelled. Blocks that could not be
+// cancelled are returned with the error.
+func bookBlocks(ctx context.Context, booker blockBooker, userID, tenantID string, blocks []bookingBlock, players int, paymentMethod string) ([]bookedBlock, error) {
+	intentIDs := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		intentID, err := preparePaymentIntent(ctx, booker, userID, tenantID, block, players, paymentMethod)
+		if err != nil {
+			if len(blocks) > 1 {
+				return nil, fmt.Errorf("%s %s: %w (nothing was booked)", block.ResourceName, block.Start.Format("15:04"), err)
+			}
+			return nil, err
+		}
+		intentIDs = append(intentIDs, intentID)
+	}
+
+	booked := make([]bookedBlock, 0, len(blocks))
+	for idx, intentID := range intentIDs {
+		confirmResp, err := booker.ConfirmPaymentIntent(ctx, intentID)
+		if err != nil {
+			if idx == 0 {
+				return nil, err
+			}
+			err = fmt.Errorf("%s %s: %w", blocks[idx].ResourceName, blocks[idx].Start.Format("15:04"), err)
+			return rollbackBlocks(ctx, booker, booked, err)
+		}
+		booked = append(booked, bookedBlock{Block: idx, ID: extractBookingID(confirmResp)})
+	}
+	return booked, nil
+}
+
+// rollbackBlocks cancels the booked blocks after cause stopped a booking,
+// and returns the ones that stay booked.
+func rollbackBlocks(ctx context.Context, booker blockBooker, booked []bookedBlock, cause error) ([]bookedBlock, error) {
+	kept := []bookedBlock{}
+	for _, item := range booked {
+		if item.ID == "" {
+			kept = append(kept, item)
+			continue
+		}
+		if err := booker.CancelMatch(ctx, item.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: cancel booking %s: %v\n", item.ID, err)
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("%w (the %d courts booked before it were cancelled)", cause, len(booked))
+	}
+	return kept, cause
+}
+
+func preparePaymentIntent(ctx context.Context, booker blockBooker, userID, tenantID string, block bookingBlock, players int, paymentMethod string) (string, error) {
+	intent := api.PaymentIntentRequest{
+		AllowedPaymentMethodTypes: []string{"OFFER", "CASH", "MERCHANT_WALLET", "DIRECT", "SWISH", "IDEAL", "BANCONTACT", "PAYTRAIL", "CREDIT_CARD", "QUICK_PAY"},
+		UserID:                    userID,
+		Cart: api.PaymentIntentCart{
+			RequestedItem: api.PaymentIntentItem{
+				CartItemType:      "CUSTOMER_MATCH",
+				CartItemVoucherID: nil,
+				CartItemData: api.PaymentIntentItemData{
+					SupportsSplitPayment: true,
+					NumberOfPlayers:      players,
+					TenantID:             tenantID,
+					ResourceID:           block.ResourceID,
+					Start:                block.Start.UTC().Format("2006-01-02T15:04:05"),
+					Duration:             block.Duration,
+					MatchRegistrations: []api.MatchRegistration{
+						{UserID: userID, PayNow: true},
+					},
+				},
+			},
+		},
+	}
+
+	intentResp, err := booker.CreatePaymentIntent(ctx, intent)
+	if err != nil {
+		return "", err
+	}
+
+	availableMethods := extractPaymentMethods(intentResp.AvailablePaymentMethods)
+	selected, err := choosePaymentMethod(availableMethods, paymentMethod)
+	if err != nil {
+		return "", err
+	}
+
+	if selected != "" {
+		if err := booker.UpdatePaymentIntent(ctx, intentResp.PaymentIntentID, api.PaymentIntentUpdateRequest{SelectedPaymentMethod: selected}); err != nil {
+			return "", err
+		}
+	}
+	return intentResp.PaymentIntentID, nil
+}
+
+// reportPartialBooking lists the courts that stayed booked because a block
+// was only partly confirmed and cancelling them failed, so they can be
+// cancelled in the Playtomic app.
+func reportPartialBooking(booked []storage.Booking, kept []bookedBlock) {
+	fmt.Fprintf(os.Stderr, "\n%d courts could not be cancelled and are paid for; cancel them in the Playtomic app if you do not want them:\n", len(booked))
+	for idx, booking := range booked {
+		line := fmt.Sprintf("  %s %s %s", booking.Date, booking.Time, booking.Court)
+		if id := kept[idx].ID; id != "" {
+			line += fmt.Sprintf("  booking %s  https://app.playtomic.io/t/%s", id, id)
+		} else {
+			line += "  (Playtomic returned no booking ID)"
+		}
+		fmt.Fprintln(os.Stderr, line)
+	}
+}
+
+type slotMatch struct {
+	Slot         api.Slot
+	ResourceID   string
+	ResourceName string
+	Minutes      int
+}
+
+func selectSlot(resources []api.AvailabilityResource, resourceInfo map[string]api.Resource, targetDate, venueTimezone string, filter slotFilter, court string) (slotMatch, error) {
 	court = strings.TrimSpace(court)
-	matches := []struct {
-		Slot         api.Slot
-		ResourceID   string
-		ResourceName string
-	}{}
+	matches := []slotMatch{}
 
 	for _, resource := range resources {
+		resInfo, hasInfo := resourceInfo[resource.ResourceID]
 		name := resource.ResourceID
-		if label, ok := resourceNames[resource.ResourceID]; ok && label != "" {
-			name = label
+		if hasInfo && resInfo.Name != "" {
+			name = resInfo.Name
 		}
 		if court != "" && !strings.EqualFold(name, court) {
 			continue
 		}
+		// An explicit --court wins over the venue's court rules.
+		if court == "" && !filter.allowsCourt(name) {
+			continue
+		}
+		if !filter.matchesResource(resInfo, hasInfo) {
+			continue
+		}
 		for _, slot := range resource.Slots {
+			if !filter.matchesSlot(slot) {
+				continue
+			}
 			resourceDate := resource.StartDate
 			if strings.Contains(resourceDate, "T") && len(resourceDate) >= 10 {
 				resourceDate = resourceDate[:10]
 			}
 			localDate, localTime, _, ok := apiUTCDateTimeToLocal(resourceDate, slot.StartTime, venueTimezone)
-			if ok && targetDate != "" && localDate != targetDate {
-				continue
+			if !ok {
+				localDate = ""
 			}
 			if localTime == "" {
 				localTime = timeLabel(slot.StartTime)
 			}
-			minutes, err := slotMinutes(localTime)
-			if err != nil {
+			minutes, ok := filter.slotStart(localDate, localTime, targetDate)
+			if !ok || !filter.matchesTime(minutes, slot.Duration) {
 				continue
 			}
-			if minutes != targetMinutes {
-				continue
-			}
-			if duration > 0 && slot.Duration != duration {
-				continue
-			}
-			matches = append(matches, struct {
-				Slot         api.Slot
-				ResourceID   string
-				ResourceName string
-			}{Slot: slot, ResourceID: resource.ResourceID, ResourceName: name})
+			matches = append(matches, slotMatch{Slot: slot, ResourceID: resource.ResourceID, ResourceName: name, Minutes: minutes})
 		}
 	}
 
 	if len(matches) == 0 {
-		return api.Slot{}, "", "", fmt.Errorf("slot not available for %s", courtOrAny(court))
+		return slotMatch{}, fmt.Errorf("slot not available for %s", courtOrAny(court))
 	}
 
 	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Minutes != matches[j].Minutes {
+			return matches[i].Minutes < matches[j].Minutes
+		}
+		if left, right := filter.courtRank(matches[i].ResourceName), filter.courtRank(matches[j].ResourceName); left != right {
+			return left < right
+		}
 		return matches[i].ResourceName < matches[j].ResourceName
 	})
-	chosen := matches[0]
-	return chosen.Slot, chosen.ResourceID, chosen.ResourceName, nil
+	return matches[0], nil
 }
 
 func courtOrAny(court string) string {
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"padel-cli/api"
+)
+
+// fakeBooker confirms intents in order, failing the ones in failConfirm,
+// and records what it confirmed and cancelled.
+type fakeBooker struct {
+	intents     int
+	failConfirm map[string]bool
+	failCancel  map[string]bool
+	confirmed   []string
+	cancelled   []string
+}
+
+func (f *fakeBooker) CreatePaymentIntent(ctx context.Context, payload api.PaymentIntentRequest) (api.PaymentIntentResponse, error) {
+	f.intents++
+	return api.PaymentIntentResponse{PaymentIntentID: fmt.Sprintf("intent-%d", f.intents)}, nil
+}
+
+func (f *fakeBooker) UpdatePaymentIntent(ctx context.Context, paymentIntentID string, payload api.PaymentIntentUpdateRequest) error {
+	return nil
+}
+
+func (f *fakeBooker) ConfirmPaymentIntent(ctx context.Context, paymentIntentID string) (map[string]any, error) {
+	if f.failConfirm[paymentIntentID] {
+		return nil, errors.New("payment declined")
+	}
+	f.confirmed = append(f.confirmed, paymentIntentID)
+	return map[string]any{"match_id": "match-" + paymentIntentID}, nil
+}
+
+func (f *fakeBooker) CancelMatch(ctx context.Context, matchID string) error {
+	if f.failCancel[matchID] {
+		return errors.New("too late to cancel")
+	}
+	f.cancelled = append(f.cancelled, matchID)
+	return nil
+}
+
+func testBlocks(n int) []bookingBlock {
+	start := time.Date(2026, 10, 24, 10, 0, 0, 0, time.UTC)
+	blocks := []bookingBlock{}
+	for i := range n {
+		blocks = append(blocks, bookingBlock{ResourceID: fmt.Sprintf("r%d", i+1), ResourceName: fmt.Sprintf("Court %d", i+1), Start: start, Duration: 90})
+	}
+	return blocks
+}
+
+func TestBookBlocksBooksEveryBlock(t *testing.T) {
+	booker := &fakeBooker{}
+	booked, err := bookBlocks(context.Background(), booker, "me", "t1", testBlocks(2), 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bookedBlock{{Block: 0, ID: "match-intent-1"}, {Block: 1, ID: "match-intent-2"}}
+	if !reflect.DeepEqual(booked, want) {
+		t.Errorf("bookBlocks = %v, want %v", booked, want)
+	}
+}
+
+func TestBookBlocksRollsBackWhenSecondConfirmFails(t *testing.T) {
+	booker := &fakeBooker{failConfirm: map[string]bool{"intent-2": true}}
+	booked, err := bookBlocks(context.Background(), booker, "me", "t1", testBlocks(3), 4, "")
+	if err == nil {
+		t.Fatal("bookBlocks succeeded, want an error")
+	}
+	if len(booked) != 0 {
+		t.Errorf("bookBlocks kept %v, want everything cancelled", booked)
+	}
+	if !reflect.DeepEqual(booker.confirmed, []string{"intent-1"}) {
+		t.Errorf("confirmed %v, want only intent-1", booker.confirmed)
+	}
+	if !reflect.DeepEqual(booker.cancelled, []string{"match-intent-1"}) {
+		t.Errorf("cancelled %v, want match-intent-1", booker.cancelled)
+	}
+}
+
+func TestBookBlocksKeepsBlocksThatCannotBeCancelled(t *testing.T) {
+	booker := &fakeBooker{
+		failConfirm: map[string]bool{"intent-3": true},
+		failCancel:  map[string]bool{"match-intent-2": true},
+	}
+	booked, err := bookBlocks(context.Background(), booker, "me", "t1", testBlocks(3), 4, "")
+	if err == nil {
+		t.Fatal("bookBlocks succeeded, want an error")
+	}
+	if !reflect.DeepEqual(booker.cancelled, []string{"match-intent-1"}) {
+		t.Errorf("cancelled %v, want match-intent-1", booker.cancelled)
+	}
+	want := []bookedBlock{{Block: 1, ID: "match-intent-2"}}
+	if !reflect.DeepEqual(booked, want) {
+		t.Errorf("bookBlocks kept %v, want %v", booked, want)
+	}
+}
+
+func TestBookBlocksFirstConfirmFails(t *testing.T) {
+	booker := &fakeBooker{failConfirm: map[string]bool{"intent-1": true}}
+	booked, err := bookBlocks(context.Background(), booker, "me", "t1", testBlocks(2), 4, "")
+	if err == nil || len(booked) != 0 {
+		t.Errorf("bookBlocks = %v, %v, want nothing booked and an error", booked, err)
+	}
+	if len(booker.confirmed) != 0 || len(booker.cancelled) != 0 {
+		t.Errorf("confirmed %v and cancelled %v, want neither", booker.confirmed, booker.cancelled)
+	}
+}
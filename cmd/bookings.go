@@ -6,21 +6,33 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"text/tabwriter"
 	"time"
 
+	"padel-cli/api"
 	"padel-cli/storage"
 
 	"github.com/spf13/cobra"
 )
 
 type BookingStats struct {
-	TotalBookings       int     `json:"total_bookings"`
-	TotalSpent          float64 `json:"total_spent"`
-	FavouriteVenue      string  `json:"favourite_venue"`
-	FavouriteVenueCount int     `json:"favourite_venue_count"`
-	UsualTime           string  `json:"usual_time"`
-	LastPlayed          string  `json:"last_played"`
+	TotalBookings       int         `json:"total_bookings"`
+	TotalSpent          []api.Money `json:"total_spent"`
+	FavouriteVenue      string      `json:"favourite_venue"`
+	FavouriteVenueCount int         `json:"favourite_venue_count"`
+	UsualTime           string      `json:"usual_time"`
+	LastPlayed          string      `json:"last_played"`
+	AveragePerHour      []api.Money `json:"average_per_hour"`
+	LongestStreakWeeks  int         `json:"longest_streak_weeks"`
+	LongestStreakStart  string      `json:"longest_streak_start,omitempty"`
+	Indoor              int         `json:"indoor"`
+	Outdoor             int         `json:"outdoor"`
+	UnknownCourtType    int         `json:"unknown_court_type"`
+
+	ByYear  []StatsBucket `json:"by_year"`
+	ByMonth []StatsBucket `json:"by_month"`
+	ByWeek  []StatsBucket `json:"by_week"`
+	ByVenue []StatsBucket `json:"by_venue"`
+	ByCourt []StatsBucket `json:"by_court"`
 }
 
 func bookingsCmd() *cobra.Command {
@@ -35,20 +47,38 @@ func bookingsCmd() *cobra.Command {
 	cmd.AddCommand(bookingsRemoveCmd())
 	cmd.AddCommand(bookingsStatsCmd())
 	cmd.AddCommand(bookingsSyncCmd())
+	cmd.AddCommand(bookingsExportCmd())
+	cmd.AddCommand(bookingsImportCmd())
 	return cmd
 }
 
 func bookingsListCmd() *cobra.Command {
 	var past bool
+	var all bool
+	var date string
 	var from string
 	var to string
+	var query bookingQuery
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List bookings",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			filter := storage.BookingFilter{}
+			filter, err := query.filter()
+			if err != nil {
+				return err
+			}
 
+			if date != "" {
+				if from != "" || to != "" {
+					return fmt.Errorf("use either --date or --from/--to, not both")
+				}
+				days, err := parseDateRangeInLocation(date, time.Local)
+				if err != nil {
+					return err
+				}
+				filter.Dates = formatDays(days)
+			}
 			if from != "" {
 				date, err := parseDateInput(from)
 				if err != nil {
@@ -67,65 +97,44 @@ func bookingsListCmd() *cobra.Command {
 				return fmt.Errorf("--from must be on or before --to")
 			}
 
-			now := time.Now()
-			filter.NowDate = now.Format("2006-01-02")
-			filter.NowTime = now.Format("15:04")
+			filter.Now = time.Now().UTC().Format(time.RFC3339)
 
-			if filter.From == "" && filter.To == "" {
+			if all && past {
+				return fmt.Errorf("use either --all or --past, not both")
+			}
+			if filter.From == "" && filter.To == "" && len(filter.Dates) == 0 && !all {
 				if past {
 					filter.Past = true
 				} else {
+					filter.InProgress = true
 					filter.Upcoming = true
 				}
 			}
 
-			db, err := storage.OpenBookingsDB()
+			bookings, err := loadBookings(filter)
 			if err != nil {
 				return err
 			}
-			defer db.Close()
 
-			bookings, err := storage.ListBookings(db, filter)
-			if err != nil {
-				return err
-			}
-			venueByID, venueByAlias := buildVenueLookups()
-			for i := range bookings {
-				ensureBookingTimezone(&bookings[i], venueByID, venueByAlias)
-			}
-
-			if outputJSON {
-				return writeJSON(bookings)
-			}
-
-			if len(bookings) == 0 {
-				fmt.Println("No bookings found.")
-				return nil
-			}
-
-			writer := tabwriter.NewWriter(os.Stdout, 2, 2, 2, ' ', 0)
-			if !outputCompact {
-				fmt.Fprintln(writer, "DAY\tDATE\tTIME\tVENUE\tCOURT\tPRICE\tLINK")
-			}
-			for _, booking := range bookings {
-				price := formatEUR(booking.Price)
-				day := ""
-				if parsed, err := time.Parse("2006-01-02", booking.Date); err == nil {
-					day = parsed.Weekday().String()[:3]
-				}
-				link := ""
-				if booking.ID != "" && booking.Source == "playtomic_sync" {
-					link = fmt.Sprintf("https://app.playtomic.io/t/%s", booking.ID)
-				}
-				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", day, booking.Date, booking.Time, booking.VenueName, booking.Court, price, link)
-			}
-			return writer.Flush()
+			return render(renderSpec{
+				Data:  bookings,
+				Empty: "No bookings found.",
+				Table: func() tableData {
+					return bookingsTable(bookings)
+				},
+				Chat: func(c chatFormatter) string {
+					return chatBookings(c, bookings)
+				},
+			})
 		},
 	}
 
-	cmd.Flags().BoolVar(&past, "past", false, "List past bookings")
-	cmd.Flags().StringVar(&from, "from", "", "Start date (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&to, "to", "", "End date (YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&past, "past", false, "List bookings that have ended")
+	cmd.Flags().BoolVar(&all, "all", false, "List past and upcoming bookings")
+	cmd.Flags().StringVar(&date, "date", "", "Date expression (e.g. this week, sat,sun, 2026-10-20..2026-10-26)")
+	cmd.Flags().StringVar(&from, "from", "", "Start date (YYYY-MM-DD, yesterday, -2w, ...)")
+	cmd.Flags().StringVar(&to, "to", "", "End date (YYYY-MM-DD, today, +1w, ...)")
+	addBookingQueryFlags(cmd, &query)
 	return cmd
 }
 
@@ -167,20 +176,12 @@ func bookingsShowCmd() *cobra.Command {
 			if len(args) > 0 {
 				matchID = args[0]
 			} else {
-				// Get the next upcoming booking
-				db, err := storage.OpenBookingsDB()
-				if err != nil {
-					return err
-				}
-				defer db.Close()
-
-				now := time.Now()
-				filter := storage.BookingFilter{
-					NowDate:  now.Format("2006-01-02"),
-					NowTime:  now.Format("15:04"),
-					Upcoming: true,
-				}
-				bookings, err := storage.ListBookings(db, filter)
+				// Get the game being played, or else the next upcoming one
+				bookings, err := loadBookings(storage.BookingFilter{
+					Now:        time.Now().UTC().Format(time.RFC3339),
+					InProgress: true,
+					Upcoming:   true,
+				})
 				if err != nil {
 					return err
 				}
@@ -200,57 +201,7 @@ func bookingsShowCmd() *cobra.Command {
 				return fmt.Errorf("failed to get match details: %v", err)
 			}
 
-			if outputJSON {
-				return writeJSON(details)
-			}
-
-			// Count players
-			totalPlayers := 0
-			maxPlayers := 0
-			var playerNames []string
-			for _, team := range details.Teams {
-				for _, player := range team.Players {
-					totalPlayers++
-					name := player.Name
-					if player.UserID == details.OwnerID {
-						name += "*"
-					}
-					playerNames = append(playerNames, name)
-				}
-				maxPlayers += team.MaxPlayers
-			}
-
-			if outputCompact {
-				// Compact: "3/4: Josh*, Marcos, Martijn"
-				fmt.Printf("%d/%d: %s\n", totalPlayers, maxPlayers, strings.Join(playerNames, ", "))
-				return nil
-			}
-
-			// Normal output
-			fmt.Printf("Match: %s\n", details.MatchID)
-			fmt.Printf("Venue: %s\n", details.Location)
-			fmt.Printf("Court: %s\n", details.ResourceName)
-			fmt.Printf("Date: %s\n", details.StartDate)
-			fmt.Printf("Price: %s\n", details.Price)
-			fmt.Printf("Status: %s\n", details.Status)
-			fmt.Println()
-
-			fmt.Printf("Players (%d/%d):\n", totalPlayers, maxPlayers)
-			for _, team := range details.Teams {
-				for _, player := range team.Players {
-					owner := ""
-					if player.UserID == details.OwnerID {
-						owner = " (organizer)"
-					}
-					fmt.Printf("  - %s%s\n", player.Name, owner)
-				}
-			}
-
-			if totalPlayers < maxPlayers {
-				fmt.Printf("  - (%d empty slots)\n", maxPlayers-totalPlayers)
-			}
-
-			return nil
+			return render(matchDetailsSpec(details, inviteSuggestions(details, creds.UserID, 5)))
 		},
 	}
 
@@ -263,6 +214,7 @@ func bookingsAddCmd() *cobra.Command {
 	var timeValue string
 	var court string
 	var price float64
+	var currency string
 	var duration int
 
 	cmd := &cobra.Command{
@@ -278,6 +230,10 @@ func bookingsAddCmd() *cobra.Command {
 			if duration <= 0 {
 				duration = 90
 			}
+			currency = strings.ToUpper(strings.TrimSpace(currency))
+			if len(currency) != 3 {
+				return fmt.Errorf("--currency must be an ISO 4217 code such as EUR")
+			}
 
 			if _, err := parseClock(timeValue); err != nil {
 				return err
@@ -311,6 +267,7 @@ func bookingsAddCmd() *cobra.Command {
 				VenueTimezone: venueTZ,
 				Duration:      duration,
 				Price:         price,
+				Currency:      currency,
 				BookedAt:      time.Now().UTC().Format(time.RFC3339),
 				Source:        "manual",
 			}
@@ -335,6 +292,7 @@ func bookingsAddCmd() *cobra.Command {
 	cmd.Flags().StringVar(&timeValue, "time", "", "Time (HH:MM)")
 	cmd.Flags().StringVar(&court, "court", "", "Court name")
 	cmd.Flags().Float64Var(&price, "price", 0, "Price")
+	cmd.Flags().StringVar(&currency, "currency", defaultCurrency, "Price currency (ISO 4217)")
 	cmd.Flags().IntVar(&duration, "duration", 90, "Duration in minutes")
 	return cmd
 }
@@ -369,66 +327,110 @@ func bookingsRemoveCmd() *cobra.Command {
 }
 
 func bookingsStatsCmd() *cobra.Command {
+	var from string
+	var to string
+	var venueAliases string
+	var by string
+	var all bool
+
 	cmd := &cobra.Command{
 		Use:   "stats",
 		Short: "Show booking stats",
+		Long:  "Show stats of played bookings (add --all to count upcoming ones too): totals, average price per hour, the longest run of weeks played, indoor vs outdoor, a weekly sparkline and bar charts per period, venue or court. Json output includes every breakdown.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			db, err := storage.OpenBookingsDB()
+			breakdowns, err := parseStatsBreakdowns(by)
 			if err != nil {
 				return err
 			}
-			defer db.Close()
 
-			bookings, err := storage.ListBookings(db, storage.BookingFilter{})
+			filter := storage.BookingFilter{}
+			if from != "" {
+				date, err := parseDateInput(from)
+				if err != nil {
+					return err
+				}
+				filter.From = date.Format("2006-01-02")
+			}
+			if to != "" {
+				date, err := parseDateInput(to)
+				if err != nil {
+					return err
+				}
+				filter.To = date.Format("2006-01-02")
+			}
+			if filter.From != "" && filter.To != "" && filter.From > filter.To {
+				return fmt.Errorf("--from must be on or before --to")
+			}
+
+			filter.Venues, err = venueFilterValues(venueAliases)
 			if err != nil {
 				return err
 			}
-			venueByID, venueByAlias := buildVenueLookups()
-			for i := range bookings {
-				ensureBookingTimezone(&bookings[i], venueByID, venueByAlias)
-			}
-			if len(bookings) == 0 {
-				fmt.Println("No bookings found.")
-				return nil
+			// Stats describe games played, so upcoming and in-progress
+			// bookings only count when asked for.
+			if !all {
+				filter.Past = true
+				filter.Now = time.Now().UTC().Format(time.RFC3339)
 			}
 
-			stats := computeBookingStats(bookings)
-			if outputJSON {
-				return writeJSON(stats)
+			bookings, err := loadBookings(filter)
+			if err != nil {
+				return err
 			}
 
-			fmt.Printf("Total bookings: %d\n", stats.TotalBookings)
-			fmt.Printf("Total spent: %s\n", formatEUR(stats.TotalSpent))
-			fmt.Printf("Favourite venue: %s (%d bookings)\n", stats.FavouriteVenue, stats.FavouriteVenueCount)
-			fmt.Printf("Usual time: %s\n", stats.UsualTime)
-			fmt.Printf("Last played: %s\n", stats.LastPlayed)
-			return nil
+			venueByID, venueByAlias := buildVenueLookups()
+			stats := computeBookingStats(bookings, venueByID, venueByAlias, time.Now())
+			headers := []string{"TOTAL_BOOKINGS", "TOTAL_SPENT", "AVERAGE_PER_HOUR", "FAVOURITE_VENUE", "FAVOURITE_VENUE_COUNT", "USUAL_TIME", "LAST_PLAYED", "LONGEST_STREAK_WEEKS", "INDOOR", "OUTDOOR"}
+			if len(bookings) == 0 {
+				return render(renderSpec{
+					Data:  stats,
+					Table: func() tableData { return tableData{Headers: headers} },
+					Empty: "No bookings found.",
+				})
+			}
+			return render(renderSpec{
+				Data: stats,
+				Table: func() tableData {
+					return tableData{
+						Headers: headers,
+						Rows: [][]string{{
+							fmt.Sprintf("%d", stats.TotalBookings),
+							plainMoneyList(stats.TotalSpent),
+							plainMoneyList(stats.AveragePerHour),
+							stats.FavouriteVenue,
+							fmt.Sprintf("%d", stats.FavouriteVenueCount),
+							stats.UsualTime,
+							stats.LastPlayed,
+							fmt.Sprintf("%d", stats.LongestStreakWeeks),
+							fmt.Sprintf("%d", stats.Indoor),
+							fmt.Sprintf("%d", stats.Outdoor),
+						}},
+					}
+				},
+				Text: func() error {
+					return renderBookingStats(stats, breakdowns)
+				},
+			})
 		},
 	}
 
+	cmd.Flags().StringVar(&from, "from", "", "Only count bookings on/after this date")
+	cmd.Flags().StringVar(&to, "to", "", "Only count bookings on/before this date")
+	cmd.Flags().StringVar(&venueAliases, "venue", "", "Only count bookings at these saved venues (comma-separated, @group)")
+	cmd.Flags().StringVar(&by, "by", "month,venue", "Breakdowns to chart (year,month,week,venue,court)")
+	cmd.Flags().BoolVar(&all, "all", false, "Also count in-progress and upcoming bookings")
 	return cmd
 }
 
 func bookingsSyncCmd() *cobra.Command {
 	var from string
 	var size int
+	var skipPlayers bool
 
 	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "Sync bookings from Playtomic",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			creds, err := storage.LoadCredentials()
-			if err != nil {
-				return err
-			}
-			if creds == nil || creds.AccessToken == "" {
-				return fmt.Errorf("not logged in. Run 'padel auth login' first")
-			}
-			if creds.AccessTokenExpired(time.Now()) {
-				return fmt.Errorf("token expired. Run 'padel auth login' to re-authenticate")
-			}
-			client.AccessToken = creds.AccessToken
-
 			fromDate := time.Time{}
 			if from != "" {
 				parsed, err := parseDateInput(from)
@@ -438,111 +440,281 @@ func bookingsSyncCmd() *cobra.Command {
 				fromDate = parsed
 			}
 
-			if size <= 0 {
-				size = 50
-			}
-
-			ctx := context.Background()
-			matches, err := client.GetMatches(ctx, size, "start_date,DESC", creds.UserID)
+			result, err := syncBookings(context.Background(), fromDate, size, !skipPlayers)
 			if err != nil {
 				return err
 			}
 
-			venues, err := storage.LoadVenues()
-			if err != nil {
-				return err
-			}
-			venueByID := map[string]storage.Venue{}
-			for _, venue := range venues {
-				venueByID[venue.ID] = venue
+			summary := map[string]int{
+				"synced":           result.Added,
+				"skipped":          result.Skipped,
+				"cancelled":        result.Cancelled,
+				"rosters":          result.Rosters,
+				"total_in_account": result.Total,
 			}
+			return render(renderSpec{
+				Data: summary,
+				Table: func() tableData {
+					return tableData{
+						Headers: []string{"SYNCED", "SKIPPED", "CANCELLED", "TOTAL_IN_ACCOUNT"},
+						Rows:    [][]string{{fmt.Sprintf("%d", result.Added), fmt.Sprintf("%d", result.Skipped), fmt.Sprintf("%d", result.Cancelled), fmt.Sprintf("%d", result.Total)}},
+					}
+				},
+				Text: func() error {
+					fmt.Printf("Sync complete. Added %d, skipped %d, removed %d cancelled (total %d).\n", result.Added, result.Skipped, result.Cancelled, result.Total)
+					if result.Rosters > 0 {
+						fmt.Printf("Fetched players for %d matches.\n", result.Rosters)
+					}
+					return nil
+				},
+			})
+		},
+	}
 
-			db, err := storage.OpenBookingsDB()
+	cmd.Flags().StringVar(&from, "from", "", "Only sync bookings on/after this date (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&size, "size", 50, "Number of matches to fetch")
+	cmd.Flags().BoolVar(&skipPlayers, "skip-players", false, "Do not fetch who played in each match")
+	return cmd
+}
+
+type syncResult struct {
+	Added     int
+	Skipped   int
+	Cancelled int
+	Total     int
+	Rosters   int
+}
+
+// syncBookings stores new matches from the Playtomic account and removes
+// matches that have been cancelled since they were synced. With rosters it
+// also fetches the players of new and upcoming matches. Every sync records
+// the user's current level in the level history.
+func syncBookings(ctx context.Context, fromDate time.Time, size int, rosters bool) (syncResult, error) {
+	result := syncResult{}
+
+	creds, err := storage.LoadCredentials()
+	if err != nil {
+		return result, err
+	}
+	if creds == nil || creds.AccessToken == "" {
+		return result, fmt.Errorf("not logged in. Run 'padel auth login' first")
+	}
+	if creds.AccessTokenExpired(time.Now()) {
+		return result, fmt.Errorf("token expired. Run 'padel auth login' to re-authenticate")
+	}
+	client.AccessToken = creds.AccessToken
+
+	if size <= 0 {
+		size = 50
+	}
+
+	matches, err := client.GetMatches(ctx, size, "start_date,DESC", creds.UserID)
+	if err != nil {
+		return result, err
+	}
+
+	venues, err := storage.LoadVenues()
+	if err != nil {
+		return result, err
+	}
+	venueByID := map[string]storage.Venue{}
+	for _, venue := range venues {
+		venueByID[venue.ID] = venue
+	}
+
+	db, err := storage.OpenBookingsDB()
+	if err != nil {
+		return result, err
+	}
+	defer db.Close()
+
+	synced := []api.Match{}
+	for _, match := range matches {
+		result.Total++
+		start, ok := parseAPIDateTime(match.StartDate)
+		if ok && !fromDate.IsZero() && start.Before(fromDate) {
+			continue
+		}
+
+		if matchCancelled(match) {
+			removed, err := storage.RemoveBooking(db, match.MatchID)
 			if err != nil {
-				return err
+				return result, err
 			}
-			defer db.Close()
+			if removed {
+				result.Cancelled++
+			}
+			continue
+		}
 
-			total := 0
-			added := 0
-			skipped := 0
-			for _, match := range matches {
-				total++
-				start, ok := parseAPIDateTime(match.StartDate)
-				if ok && !fromDate.IsZero() && start.Before(fromDate) {
-					continue
-				}
+		venueTZ := match.Tenant.Address.TimeZone
+		if venue, ok := venueByID[match.Tenant.TenantID]; ok {
+			venueTZ = venue.TimeZone
+		}
+		localDate, localTime, startUTC, _ := apiUTCToLocal(match.StartDate, venueTZ)
+		if localDate == "" {
+			localDate = dateFromMatch(match.StartDate)
+		}
+		if localTime == "" {
+			localTime = timeFromMatch(match.StartDate)
+		}
 
-				venueTZ := match.Tenant.Address.TimeZone
-				if venue, ok := venueByID[match.Tenant.TenantID]; ok {
-					venueTZ = venue.TimeZone
-				}
-				localDate, localTime, startUTC, _ := apiUTCToLocal(match.StartDate, venueTZ)
-				if localDate == "" {
-					localDate = dateFromMatch(match.StartDate)
-				}
-				if localTime == "" {
-					localTime = timeFromMatch(match.StartDate)
-				}
+		// A price that cannot be parsed is stored as unknown, not as free.
+		price, _ := parsePrice(match.Price, venueTZ)
+		booking := storage.Booking{
+			ID:            match.MatchID,
+			VenueName:     match.Tenant.TenantName,
+			VenueID:       match.Tenant.TenantID,
+			Court:         match.ResourceName,
+			Date:          localDate,
+			Time:          localTime,
+			StartUTC:      startUTC,
+			VenueTimezone: normalizeVenueTimezone(venueTZ),
+			Duration:      durationFromMatch(match.StartDate, match.EndDate),
+			Price:         price.Amount,
+			Currency:      price.Currency,
+			BookedAt:      match.CreatedAt,
+			Source:        "playtomic_sync",
+		}
 
-				booking := storage.Booking{
-					ID:            match.MatchID,
-					VenueName:     match.Tenant.TenantName,
-					VenueID:       match.Tenant.TenantID,
-					Court:         match.ResourceName,
-					Date:          localDate,
-					Time:          localTime,
-					StartUTC:      startUTC,
-					VenueTimezone: normalizeVenueTimezone(venueTZ),
-					Duration:      durationFromMatch(match.StartDate, match.EndDate),
-					Price:         parsePriceAmount(match.Price),
-					BookedAt:      match.CreatedAt,
-					Source:        "playtomic_sync",
-				}
+		if venue, ok := venueByID[booking.VenueID]; ok {
+			booking.VenueAlias = venue.Alias
+		}
+		if booking.VenueName == "" {
+			booking.VenueName = booking.VenueAlias
+		}
 
-				if venue, ok := venueByID[booking.VenueID]; ok {
-					booking.VenueAlias = venue.Alias
-				}
-				if booking.VenueName == "" {
-					booking.VenueName = booking.VenueAlias
-				}
+		inserted, err := storage.AddBookingIfNotExists(db, booking)
+		if err != nil {
+			return result, err
+		}
+		if inserted {
+			result.Added++
+		} else {
+			result.Skipped++
+		}
+		synced = append(synced, match)
+	}
 
-				inserted, err := storage.AddBookingIfNotExists(db, booking)
-				if err != nil {
-					return err
-				}
-				if inserted {
-					added++
-				} else {
-					skipped++
+	if rosters {
+		result.Rosters, err = syncRosters(ctx, db, synced)
+		if err != nil {
+			return result, err
+		}
+	}
+	if _, err := recordLevel(ctx, db, creds.UserID); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: level not recorded: %v\n", err)
+	}
+	return result, nil
+}
+
+func matchCancelled(match api.Match) bool {
+	status := strings.ToUpper(match.Status)
+	return status == "CANCELED" || status == "CANCELLED"
+}
+
+func bookingsTable(bookings []storage.Booking) tableData {
+	table := tableData{Headers: []string{"DAY", "DATE", "TIME", "VENUE", "COURT", "PRICE", "LINK"}}
+	for _, booking := range bookings {
+		day := ""
+		if parsed, err := time.Parse("2006-01-02", booking.Date); err == nil {
+			day = parsed.Weekday().String()[:3]
+		}
+		clock := booking.Time
+		if booking.InProgress {
+			clock += " (playing)"
+		}
+		table.Rows = append(table.Rows, []string{day, booking.Date, clock, booking.VenueName, booking.Court, formatMoney(bookingMoney(booking)), bookingLink(booking)})
+	}
+	return table
+}
+
+// bookingLink returns the Playtomic page for synced bookings.
+func bookingLink(booking storage.Booking) string {
+	if booking.ID == "" || booking.Source != "playtomic_sync" {
+		return ""
+	}
+	return fmt.Sprintf("https://app.playtomic.io/t/%s", booking.ID)
+}
+
+func matchDetailsSpec(details api.MatchDetails, invite []string) renderSpec {
+	totalPlayers := 0
+	maxPlayers := 0
+	var playerNames []string
+	for _, team := range details.Teams {
+		for _, player := range team.Players {
+			totalPlayers++
+			name := player.Name
+			if player.UserID == details.OwnerID {
+				name += "*"
+			}
+			playerNames = append(playerNames, name)
+		}
+		maxPlayers += team.MaxPlayers
+	}
+
+	return renderSpec{
+		Data: details,
+		Table: func() tableData {
+			table := tableData{Headers: []string{"TEAM", "PLAYER", "ORGANIZER", "LEVEL"}}
+			for _, team := range details.Teams {
+				for _, player := range team.Players {
+					organizer := "no"
+					if player.UserID == details.OwnerID {
+						organizer = "yes"
+					}
+					table.Rows = append(table.Rows, []string{team.TeamID, player.Name, organizer, fmt.Sprintf("%.2f", player.LevelValue)})
 				}
 			}
+			return table
+		},
+		Chat: func(c chatFormatter) string {
+			return chatRoster(c, details)
+		},
+		Compact: func() error {
+			// Compact: "3/4: Josh*, Marcos, Martijn"
+			fmt.Printf("%d/%d: %s\n", totalPlayers, maxPlayers, strings.Join(playerNames, ", "))
+			return nil
+		},
+		Text: func() error {
+			fmt.Printf("Match: %s\n", details.MatchID)
+			fmt.Printf("Venue: %s\n", details.Location)
+			fmt.Printf("Court: %s\n", details.ResourceName)
+			fmt.Printf("Date: %s\n", details.StartDate)
+			fmt.Printf("Price: %s\n", details.Price)
+			fmt.Printf("Status: %s\n", details.Status)
+			fmt.Println()
 
-			if outputJSON {
-				return writeJSON(map[string]int{
-					"synced":           added,
-					"skipped":          skipped,
-					"total_in_account": total,
-				})
+			fmt.Printf("Players (%d/%d):\n", totalPlayers, maxPlayers)
+			for _, team := range details.Teams {
+				for _, player := range team.Players {
+					owner := ""
+					if player.UserID == details.OwnerID {
+						owner = " (organizer)"
+					}
+					fmt.Printf("  - %s%s\n", player.Name, owner)
+				}
 			}
 
-			fmt.Printf("Sync complete. Added %d, skipped %d (total %d).\n", added, skipped, total)
+			if totalPlayers < maxPlayers {
+				fmt.Printf("  - (%d empty slots)\n", maxPlayers-totalPlayers)
+				if len(invite) > 0 {
+					fmt.Printf("\nUsually invited: %s\n", strings.Join(invite, ", "))
+				}
+			}
 			return nil
 		},
 	}
-
-	cmd.Flags().StringVar(&from, "from", "", "Only sync bookings on/after this date (YYYY-MM-DD)")
-	cmd.Flags().IntVar(&size, "size", 50, "Number of matches to fetch")
-	return cmd
 }
 
-func computeBookingStats(bookings []storage.Booking) BookingStats {
+func computeBookingStats(bookings []storage.Booking, venueByID, venueByAlias map[string]storage.Venue, now time.Time) BookingStats {
 	stats := BookingStats{TotalBookings: len(bookings)}
 
 	venueCounts := map[string]int{}
 	venueNames := map[string]string{}
+	spent := make([]api.Money, 0, len(bookings))
 	for _, booking := range bookings {
-		stats.TotalSpent += booking.Price
+		spent = append(spent, bookingMoney(booking))
 		key := booking.VenueAlias
 		if key == "" {
 			key = booking.VenueName
@@ -553,9 +725,11 @@ func computeBookingStats(bookings []storage.Booking) BookingStats {
 		}
 	}
 
+	stats.TotalSpent = sumByCurrency(spent)
 	stats.FavouriteVenue, stats.FavouriteVenueCount = topVenue(venueCounts, venueNames)
 	stats.UsualTime = mostCommonTime(bookings)
-	stats.LastPlayed = lastPlayedDate(bookings)
+	stats.LastPlayed = lastPlayedDate(bookings, now)
+	addBreakdowns(&stats, bookings, venueByID, venueByAlias)
 	return stats
 }
 
@@ -635,40 +809,25 @@ func bookingTimeLabel(booking storage.Booking) (string, bool) {
 	return fmt.Sprintf("%s %s", weekday, booking.Time), true
 }
 
-func lastPlayedDate(bookings []storage.Booking) string {
-	var last time.Time
+// lastPlayedDate is the local date of the latest booking that ended by now.
+func lastPlayedDate(bookings []storage.Booking, now time.Time) string {
+	var last storage.Booking
+	var lastStart time.Time
 	found := false
-	now := time.Now()
 	for _, booking := range bookings {
-		if booking.Date == "" {
+		if !bookingEnded(booking, now) {
 			continue
 		}
-		dateTime := booking.Date
-		if booking.Time != "" {
-			dateTime += " " + booking.Time
-		} else {
-			dateTime += " 00:00"
-		}
-		parsed, err := time.Parse("2006-01-02 15:04", dateTime)
-		if err != nil {
-			continue
-		}
-		if parsed.After(now) {
-			continue
-		}
-		if !found || parsed.After(last) {
-			last = parsed
+		start, _ := parseAPIDateTime(booking.StartUTC)
+		if !found || start.After(lastStart) {
+			last, lastStart = booking, start
 			found = true
 		}
 	}
 	if !found {
 		return "N/A"
 	}
-	return last.Format("2006-01-02")
-}
-
-func formatEUR(amount float64) string {
-	return fmt.Sprintf("EUR %.2f", amount)
+	return last.Date
 }
 
 func buildVenueLookups() (map[string]storage.Venue, map[string]storage.Venue) {
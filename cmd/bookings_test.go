@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"padel-cli/storage"
+)
+
+func TestLastPlayedDate(t *testing.T) {
+	// 01:00 in Amsterdam on the 24th is 23:00 UTC on the 23rd.
+	now := time.Date(2026, 10, 24, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		bookings []storage.Booking
+		want     string
+	}{
+		{name: "none", want: "N/A"},
+		{name: "after midnight local", bookings: []storage.Booking{
+			{Date: "2026-10-22", Time: "20:00", StartUTC: "2026-10-22T18:00:00Z", Duration: 90},
+			{Date: "2026-10-24", Time: "01:00", StartUTC: "2026-10-23T23:00:00Z", Duration: 90},
+		}, want: "2026-10-24"},
+		{name: "still playing", bookings: []storage.Booking{
+			{Date: "2026-10-20", StartUTC: "2026-10-20T18:00:00Z", Duration: 90},
+			{Date: "2026-10-24", Time: "11:30", StartUTC: "2026-10-24T09:30:00Z", Duration: 90},
+		}, want: "2026-10-20"},
+		{name: "upcoming and unknown start", bookings: []storage.Booking{
+			{Date: "2026-10-31", StartUTC: "2026-10-31T10:00:00Z", Duration: 60},
+			{Date: "2026-10-01", Time: "10:00"},
+		}, want: "N/A"},
+	}
+	for _, tt := range tests {
+		if got := lastPlayedDate(tt.bookings, now); got != tt.want {
+			t.Errorf("%s: lastPlayedDate = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// BudgetConfig caps spend per calendar month and/or year. With PerPerson the
+// limits apply to one player's share of each booking (the price divided by
+// Players) rather than the full court price. OnExceed is "refuse" (default)
+// or "warn" and decides what book does when a booking would go over.
+type BudgetConfig struct {
+	Monthly   float64 `json:"monthly"`
+	Yearly    float64 `json:"yearly"`
+	Currency  string  `json:"currency"`
+	PerPerson bool    `json:"per_person"`
+	Players   int     `json:"players"`
+	OnExceed  string  `json:"on_exceed"`
+}
+
+const (
+	budgetRefuse = "refuse"
+	budgetWarn   = "warn"
+)
+
+// BudgetProgress is the spend against one limit.
+type BudgetProgress struct {
+	Period    string  `json:"period"`
+	Label     string  `json:"label"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Limit     float64 `json:"limit"`
+	Spent     float64 `json:"spent"`
+	Remaining float64 `json:"remaining"`
+	Currency  string  `json:"currency"`
+	PerPerson bool    `json:"per_person"`
+	Bookings  int     `json:"bookings"`
+	// Uncounted is spend in other currencies, which the limit cannot
+	// include as there are no exchange rates.
+	Uncounted []api.Money `json:"uncounted,omitempty"`
+}
+
+func (b BudgetConfig) enabled() bool {
+	return b.Monthly > 0 || b.Yearly > 0
+}
+
+func (b BudgetConfig) currency() string {
+	if b.Currency == "" {
+		return defaultCurrency
+	}
+	return strings.ToUpper(b.Currency)
+}
+
+func (b BudgetConfig) players() int {
+	if b.Players <= 0 {
+		return 4
+	}
+	return b.Players
+}
+
+func (b BudgetConfig) validate() error {
+	if b.Monthly < 0 || b.Yearly < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	switch strings.ToLower(b.OnExceed) {
+	case "", budgetRefuse, budgetWarn:
+		return nil
+	}
+	return fmt.Errorf("budget.on_exceed must be %s or %s", budgetRefuse, budgetWarn)
+}
+
+// share is the part of amount counted against the budget. Per person it is
+// always split over the configured players, so bookings made with a
+// different --players count the same way as the ones already spent.
+func (b BudgetConfig) share(amount float64) float64 {
+	if !b.PerPerson {
+		return amount
+	}
+	return amount / float64(b.players())
+}
+
+func budgetCmd() *cobra.Command {
+	var date string
+
+	cmd := &cobra.Command{
+		Use:   "budget",
+		Short: "Show spend against the configured budget",
+		Long:  "Show spend against the monthly and yearly limits in the budget section of config.json. Bookings in the history count towards the month and year they are played in, including upcoming ones.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			budget := cfg.Budget
+			if !budget.enabled() {
+				return fmt.Errorf("no budget configured; set budget.monthly and/or budget.yearly in config.json")
+			}
+			if err := budget.validate(); err != nil {
+				return err
+			}
+
+			day := time.Now()
+			if date != "" {
+				parsed, err := parseDateInput(date)
+				if err != nil {
+					return err
+				}
+				day = parsed
+			}
+
+			progress, err := loadBudgetProgress(budget, day)
+			if err != nil {
+				return err
+			}
+
+			return render(renderSpec{
+				Data: progress,
+				Table: func() tableData {
+					table := tableData{Headers: []string{"PERIOD", "LABEL", "SPENT", "LIMIT", "REMAINING", "CURRENCY", "BOOKINGS", "UNCOUNTED"}}
+					for _, item := range progress {
+						table.Rows = append(table.Rows, []string{
+							item.Period,
+							item.Label,
+							fmt.Sprintf("%.2f", item.Spent),
+							fmt.Sprintf("%.2f", item.Limit),
+							fmt.Sprintf("%.2f", item.Remaining),
+							item.Currency,
+							fmt.Sprintf("%d", item.Bookings),
+							plainMoneyList(item.Uncounted),
+						})
+					}
+					return table
+				},
+				Text: func() error {
+					if budget.PerPerson {
+						fmt.Printf("Budget per person (court price split %d ways)\n", budget.players())
+					}
+					for _, item := range progress {
+						fmt.Println(budgetLine(item))
+					}
+					return nil
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "", "Show the month and year containing this date (default today)")
+	return cmd
+}
+
+// loadBudgetProgress measures the bookings history against each configured
+// limit for the month and year containing day.
+func loadBudgetProgress(budget BudgetConfig, day time.Time) ([]BudgetProgress, error) {
+	periods := budgetPeriods(budget, day)
+	if len(periods) == 0 {
+		return nil, nil
+	}
+	from, to := periods[0].From, periods[0].To
+	for _, period := range periods {
+		if period.From < from {
+			from = period.From
+		}
+		if period.To > to {
+			to = period.To
+		}
+	}
+
+	bookings, err := loadBookings(storage.BookingFilter{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	for i := range periods {
+		uncounted := []api.Money{}
+		for _, booking := range bookings {
+			if booking.Date < periods[i].From || booking.Date > periods[i].To {
+				continue
+			}
+			money := bookingMoney(booking)
+			if !strings.EqualFold(money.Currency, periods[i].Currency) {
+				uncounted = append(uncounted, api.Money{Amount: budget.share(money.Amount), Currency: money.Currency})
+				continue
+			}
+			periods[i].Spent += budget.share(booking.Price)
+			periods[i].Bookings++
+		}
+		if len(uncounted) > 0 {
+			periods[i].Uncounted = sumByCurrency(uncounted)
+			for j := range periods[i].Uncounted {
+				periods[i].Uncounted[j].Amount = roundCents(periods[i].Uncounted[j].Amount)
+			}
+		}
+		periods[i].Spent = roundCents(periods[i].Spent)
+		periods[i].Remaining = roundCents(periods[i].Limit - periods[i].Spent)
+	}
+	return periods, nil
+}
+
+func budgetPeriods(budget BudgetConfig, day time.Time) []BudgetProgress {
+	periods := []BudgetProgress{}
+	if budget.Monthly > 0 {
+		first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		periods = append(periods, BudgetProgress{
+			Period:    "monthly",
+			Label:     first.Format("January 2006"),
+			From:      first.Format("2006-01-02"),
+			To:        first.AddDate(0, 1, -1).Format("2006-01-02"),
+			Limit:     budget.Monthly,
+			Currency:  budget.currency(),
+			PerPerson: budget.PerPerson,
+		})
+	}
+	if budget.Yearly > 0 {
+		periods = append(periods, BudgetProgress{
+			Period:    "yearly",
+			Label:     day.Format("2006"),
+			From:      fmt.Sprintf("%d-01-01", day.Year()),
+			To:        fmt.Sprintf("%d-12-31", day.Year()),
+			Limit:     budget.Yearly,
+			Currency:  budget.currency(),
+			PerPerson: budget.PerPerson,
+		})
+	}
+	return periods
+}
+
+func budgetLine(item BudgetProgress) string {
+	spent := formatMoney(api.Money{Amount: item.Spent, Currency: item.Currency})
+	limit := formatMoney(api.Money{Amount: item.Limit, Currency: item.Currency})
+	percent := 0.0
+	if item.Limit > 0 {
+		percent = item.Spent / item.Limit * 100
+	}
+	status := fmt.Sprintf("%s left", formatMoney(api.Money{Amount: item.Remaining, Currency: item.Currency}))
+	if item.Remaining < 0 {
+		status = fmt.Sprintf("%s over", formatMoney(api.Money{Amount: -item.Remaining, Currency: item.Currency}))
+	}
+	title := strings.ToUpper(item.Period[:1]) + item.Period[1:]
+	line := fmt.Sprintf("%s (%s): %s of %s %s %.0f%% - %s", title, item.Label, spent, limit, progressBar(item.Spent, item.Limit, 20), percent, status)
+	if len(item.Uncounted) > 0 {
+		line += fmt.Sprintf(" (not counted, other currencies: %s)", formatMoneyList(item.Uncounted))
+	}
+	return line
+}
+
+// progressBar draws used out of total as a fixed width bar.
+func progressBar(used, total float64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(math.Round(math.Min(used/total, 1) * float64(width)))
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// checkBudget is called before booking. It returns an error when the new
+// bookings would take spend over a limit and the budget refuses, prints a
+// warning when it only warns, and does nothing when force is set or no
+// budget is configured.
+func checkBudget(bookings []storage.Booking, force bool) error {
+	budget := cfg.Budget
+	if force || !budget.enabled() || len(bookings) == 0 {
+		return nil
+	}
+	if err := budget.validate(); err != nil {
+		return err
+	}
+
+	day, err := time.Parse("2006-01-02", bookings[0].Date)
+	if err != nil {
+		return nil
+	}
+	progress, err := loadBudgetProgress(budget, day)
+	if err != nil {
+		return err
+	}
+
+	for _, booking := range bookings {
+		if money := bookingMoney(booking); !strings.EqualFold(money.Currency, budget.currency()) {
+			fmt.Fprintf(os.Stderr, "warning: this booking (%s) is not in %s, so the budget cannot count it\n", formatMoney(money), budget.currency())
+		}
+	}
+	for _, item := range progress {
+		added := 0.0
+		for _, booking := range bookings {
+			if booking.Date < item.From || booking.Date > item.To {
+				continue
+			}
+			if !strings.EqualFold(bookingMoney(booking).Currency, item.Currency) {
+				continue
+			}
+			added += budget.share(booking.Price)
+		}
+		if added == 0 || item.Spent+added <= item.Limit+0.005 {
+			continue
+		}
+
+		message := fmt.Sprintf("this booking (%s) would take the %s budget for %s to %s of %s",
+			formatMoney(api.Money{Amount: roundCents(added), Currency: item.Currency}),
+			item.Period,
+			item.Label,
+			formatMoney(api.Money{Amount: roundCents(item.Spent + added), Currency: item.Currency}),
+			formatMoney(api.Money{Amount: item.Limit, Currency: item.Currency}),
+		)
+		if strings.EqualFold(budget.OnExceed, budgetWarn) {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", message)
+			continue
+		}
+		return fmt.Errorf("%s; use --force to book anyway", message)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+)
+
+// chatFormatter renders text for a chat platform. Every literal piece of text
+// must go through text, bold or italic so it is escaped for the platform.
+type chatFormatter struct {
+	format outputFormat
+}
+
+func (c chatFormatter) text(input string) string {
+	switch c.format {
+	case formatTelegram:
+		return escapeTelegram(input)
+	case formatSlack:
+		return escapeSlack(input)
+	default:
+		return escapeWhatsApp(input)
+	}
+}
+
+func (c chatFormatter) bold(input string) string {
+	return "*" + c.text(input) + "*"
+}
+
+func (c chatFormatter) italic(input string) string {
+	return "_" + c.text(input) + "_"
+}
+
+// escapeTelegram escapes the characters reserved by Telegram MarkdownV2.
+func escapeTelegram(input string) string {
+	var b strings.Builder
+	for _, r := range input {
+		if strings.ContainsRune("_*[]()~`>#+-=|{}.!\\", r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeSlack escapes the control characters of Slack mrkdwn. Slack has no
+// escape for formatting characters, only for &, < and >.
+func escapeSlack(input string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(input)
+}
+
+// escapeWhatsApp breaks up formatting markers. WhatsApp has no escape
+// character, but a marker only applies when it touches a word, so padding it
+// with zero-width spaces keeps names like "Padel_6" literal.
+func escapeWhatsApp(input string) string {
+	var b strings.Builder
+	for _, r := range input {
+		if strings.ContainsRune("*_~`", r) {
+			b.WriteString("\u200b")
+			b.WriteRune(r)
+			b.WriteString("\u200b")
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func chatDayLabel(date string) string {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format("Mon 2 Jan")
+}
+
+func chatSearch(c chatFormatter, results []SearchResult) string {
+	blocks := []string{}
+	for _, result := range results {
+		candidateTimes := []string{}
+		for _, club := range result.Clubs {
+			for _, slot := range club.Slots {
+				candidateTimes = append(candidateTimes, slot.Time)
+			}
+		}
+		times := uniqueSortedTimes(candidateTimes)
+
+		lines := []string{"🎾 " + c.bold(chatDayLabel(result.Date))}
+		for _, club := range result.Clubs {
+			if len(club.Slots) == 0 || len(times) == 0 {
+				label := ": no slots"
+				if club.Reason != "" {
+					label += " (" + club.Reason + ")"
+				}
+				lines = append(lines, c.bold(club.ClubName)+c.text(label))
+				continue
+			}
+			courtsByTime := map[string]int{}
+			for _, slot := range club.Slots {
+				courtsByTime[slot.Time]++
+			}
+			cells := make([]string, 0, len(times))
+			for _, t := range times {
+				if count := courtsByTime[t]; count > 0 {
+					cells = append(cells, c.text(fmt.Sprintf("%s ✅", t)))
+				} else {
+					cells = append(cells, c.text(fmt.Sprintf("%s ❌", t)))
+				}
+			}
+			lines = append(lines, c.bold(club.ClubName)+c.text(": ")+strings.Join(cells, " "))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func chatBookings(c chatFormatter, bookings []storage.Booking) string {
+	if len(bookings) == 0 {
+		return "🎾 " + c.text("No bookings found.")
+	}
+	lines := make([]string, 0, len(bookings))
+	for _, booking := range bookings {
+		when := strings.TrimSpace(fmt.Sprintf("%s %s", chatDayLabel(booking.Date), booking.Time))
+		line := "🎾 " + c.bold(when) + c.text(" @ "+booking.VenueName)
+		if booking.Court != "" {
+			line += c.text(" - " + booking.Court)
+		}
+		if booking.Price > 0 {
+			line += c.text(" - " + formatMoney(bookingMoney(booking)))
+		}
+		if booking.InProgress {
+			line += c.text(" - in progress")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func chatRoster(c chatFormatter, details api.MatchDetails) string {
+	when := details.StartDate
+	if localDate, localTime, _, ok := apiUTCToLocal(details.StartDate, details.Tenant.Address.TimeZone); ok {
+		when = fmt.Sprintf("%s %s", chatDayLabel(localDate), localTime)
+	}
+	venue := details.Tenant.TenantName
+	if venue == "" {
+		venue = details.Location
+	}
+
+	header := "🎾 " + c.bold(when)
+	if venue != "" {
+		header += c.text(" @ " + venue)
+	}
+	if details.ResourceName != "" {
+		header += c.text(" - " + details.ResourceName)
+	}
+	lines := []string{header}
+
+	totalPlayers := 0
+	maxPlayers := 0
+	names := []string{}
+	for _, team := range details.Teams {
+		for _, player := range team.Players {
+			totalPlayers++
+			name := c.text(player.Name)
+			if player.UserID == details.OwnerID {
+				name += " " + c.italic("(organizer)")
+			}
+			names = append(names, name)
+		}
+		maxPlayers += team.MaxPlayers
+	}
+
+	lines = append(lines, "👥 "+c.text(fmt.Sprintf("%d/%d: ", totalPlayers, maxPlayers))+strings.Join(names, c.text(", ")))
+	if open := maxPlayers - totalPlayers; open > 0 {
+		lines = append(lines, "✋ "+c.text(fmt.Sprintf("%d spot%s left", open, plural(open))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func chatSuggestions(c chatFormatter, suggestions []Suggestion) string {
+	if len(suggestions) == 0 {
+		return "🎾 " + c.text("No slots match.")
+	}
+	lines := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		when := fmt.Sprintf("%s %s", chatDayLabel(suggestion.Date), suggestion.Slot.Time)
+		line := c.text(fmt.Sprintf("%d. ", suggestion.Rank)) + c.bold(when) + c.text(" @ "+suggestion.ClubName+" - "+suggestion.Slot.Court)
+		if suggestion.Slot.Price != "" {
+			line += c.text(" - " + formatPriceLabel(suggestion.Slot.Price, suggestion.Slot.VenueTimezone))
+		}
+		if len(suggestion.Reasons) > 0 {
+			line += " " + c.italic(strings.Join(suggestion.Reasons, ", "))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
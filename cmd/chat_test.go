@@ -0,0 +1,91 @@
+package cmd
+
+import "testing"
+
+// telegramReserved is every character Telegram MarkdownV2 requires escaping
+// outside entities; one unescaped occurrence rejects the whole message.
+const telegramReserved = "_*[]()~`>#+-=|{}.!\\"
+
+func TestEscapeTelegramReservedCharacters(t *testing.T) {
+	for _, r := range telegramReserved {
+		input := "a" + string(r) + "b"
+		want := "a\\" + string(r) + "b"
+		if got := escapeTelegram(input); got != want {
+			t.Errorf("escapeTelegram(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEscapeTelegram(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Padel 6", want: "Padel 6"},
+		{input: "Sat 24 Oct, 10:00", want: "Sat 24 Oct, 10:00"},
+		{input: "€43.68 (2 courts)!", want: `€43\.68 \(2 courts\)\!`},
+		{input: "Court_1 - indoor", want: `Court\_1 \- indoor`},
+		{input: `C:\courts`, want: `C:\\courts`},
+		{input: "Café Ñandú 🎾", want: "Café Ñandú 🎾"},
+		{input: telegramReserved, want: `\_\*\[\]\(\)\~\` + "`" + `\>\#\+\-\=\|\{\}\.\!\\`},
+	}
+	for _, tt := range tests {
+		if got := escapeTelegram(tt.input); got != tt.want {
+			t.Errorf("escapeTelegram(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeSlack(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Padel 6", want: "Padel 6"},
+		{input: "Bat & Ball", want: "Bat &amp; Ball"},
+		{input: "<!channel> court >1", want: "&lt;!channel&gt; court &gt;1"},
+		{input: "&amp;", want: "&amp;amp;"},
+		// Slack has no escape for formatting characters; they pass through.
+		{input: "*_~`", want: "*_~`"},
+	}
+	for _, tt := range tests {
+		if got := escapeSlack(tt.input); got != tt.want {
+			t.Errorf("escapeSlack(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeWhatsApp(t *testing.T) {
+	const zw = "\u200b"
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Padel 6", want: "Padel 6"},
+		{input: "Padel_6", want: "Padel" + zw + "_" + zw + "6"},
+		{input: "*~`", want: zw + "*" + zw + zw + "~" + zw + zw + "`" + zw},
+		{input: "€43.68 (2 courts)!", want: "€43.68 (2 courts)!"},
+	}
+	for _, tt := range tests {
+		if got := escapeWhatsApp(tt.input); got != tt.want {
+			t.Errorf("escapeWhatsApp(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestChatFormatterEscapesInsideMarkers(t *testing.T) {
+	const input = "Padel_6 <indoor> (1.5h)"
+	tests := []struct {
+		format outputFormat
+		want   string
+	}{
+		{format: formatTelegram, want: `*Padel\_6 <indoor\> \(1\.5h\)*`},
+		{format: formatSlack, want: "*Padel_6 &lt;indoor&gt; (1.5h)*"},
+		{format: formatWhatsApp, want: "*Padel\u200b_\u200b6 <indoor> (1.5h)*"},
+	}
+	for _, tt := range tests {
+		if got := (chatFormatter{format: tt.format}).bold(input); got != tt.want {
+			t.Errorf("%s: bold(%q) = %q, want %q", tt.format, input, got, tt.want)
+		}
+	}
+}
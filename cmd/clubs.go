@@ -3,22 +3,25 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"sort"
-	"text/tabwriter"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 type ClubSummary struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Address string `json:"address"`
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Address       string   `json:"address"`
+	DistanceKm    *float64 `json:"distance_km,omitempty"`
+	TravelMinutes int      `json:"travel_minutes,omitempty"`
 }
 
 func clubsCmd() *cobra.Command {
 	var near string
 	var radius int
+	var maxDistance float64
+	var sortBy string
 
 	cmd := &cobra.Command{
 		Use:   "clubs",
@@ -30,47 +33,66 @@ func clubsCmd() *cobra.Command {
 			if near == "" {
 				return fmt.Errorf("--near is required (or set default_location in config)")
 			}
+			sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+			if sortBy != "name" && sortBy != "distance" {
+				return fmt.Errorf("invalid --sort %q (expected name|distance)", sortBy)
+			}
 
 			ctx := context.Background()
 			lat, lon, err := resolveLocation(ctx, near)
 			if err != nil {
 				return err
 			}
+			meter, err := newDistanceMeter(lat, lon)
+			if err != nil {
+				return err
+			}
 
 			tenants, err := client.GetTenants(ctx, lat, lon, radius)
 			if err != nil {
 				return err
 			}
 
-			sort.Slice(tenants, func(i, j int) bool {
-				return tenants[i].TenantName < tenants[j].TenantName
-			})
-
 			clubs := make([]ClubSummary, 0, len(tenants))
 			for _, tenant := range tenants {
+				distance := meter.distanceKm(tenant.Address.Coord)
+				if !withinDistance(distance, maxDistance) {
+					continue
+				}
 				clubs = append(clubs, ClubSummary{
-					ID:      tenant.TenantID,
-					Name:    tenant.TenantName,
-					Address: formatAddress(tenant.Address),
+					ID:            tenant.TenantID,
+					Name:          tenant.TenantName,
+					Address:       formatAddress(tenant.Address),
+					DistanceKm:    distance,
+					TravelMinutes: meter.travelMinutes(ctx, tenant.Address.Coord),
 				})
 			}
 
-			if outputJSON {
-				return writeJSON(clubs)
-			}
+			sort.Slice(clubs, func(i, j int) bool {
+				if sortBy == "distance" {
+					if order := compareDistance(clubs[i].DistanceKm, clubs[j].DistanceKm); order != 0 {
+						return order < 0
+					}
+				}
+				return clubs[i].Name < clubs[j].Name
+			})
 
-			writer := tabwriter.NewWriter(os.Stdout, 2, 2, 2, ' ', 0)
-			if !outputCompact {
-				fmt.Fprintln(writer, "ID\tNAME\tADDRESS")
-			}
-			for _, club := range clubs {
-				fmt.Fprintf(writer, "%s\t%s\t%s\n", club.ID, club.Name, club.Address)
-			}
-			return writer.Flush()
+			return render(renderSpec{
+				Data: clubs,
+				Table: func() tableData {
+					table := tableData{Headers: []string{"ID", "NAME", "DISTANCE", "TRAVEL", "ADDRESS"}}
+					for _, club := range clubs {
+						table.Rows = append(table.Rows, []string{club.ID, club.Name, formatDistance(club.DistanceKm), formatTravel(club.TravelMinutes), club.Address})
+					}
+					return table
+				},
+			})
 		},
 	}
 
-	cmd.Flags().StringVar(&near, "near", "", "Location name or lat,lon")
+	cmd.Flags().StringVar(&near, "near", "", "Saved location name, place name or lat,lon")
 	cmd.Flags().IntVar(&radius, "radius", 50000, "Search radius in meters")
+	cmd.Flags().Float64Var(&maxDistance, "max-distance", 0, "Only show clubs within this many km")
+	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort clubs by name or distance")
 	return cmd
 }
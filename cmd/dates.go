@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDateSpan caps how many days one expression may expand to, so a typo in a
+// range does not turn into hundreds of availability requests.
+const maxDateSpan = 92
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "weds": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseDateExpr evaluates a date expression relative to now and returns the
+// matching days, in order, at midnight in now's location. It understands
+// single dates ("today", "sat", "next saturday", "+3d", "2026-10-20"), ranges
+// ("this week", "weekend", "2026-10-20..2026-10-26"), comma-separated lists
+// ("sat,sun") and "every saturday in november".
+func parseDateExpr(input string, now time.Time) ([]time.Time, error) {
+	expr := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	if expr == "" {
+		return nil, fmt.Errorf("date is required")
+	}
+	today := startOfDay(now)
+
+	var days []time.Time
+	var err error
+	switch {
+	case strings.Contains(expr, ".."):
+		days, err = parseDateSpan(expr, today)
+	case strings.HasPrefix(expr, "every "):
+		days, err = parseEveryWeekday(strings.TrimPrefix(expr, "every "), today)
+	case strings.Contains(expr, ","):
+		for _, part := range strings.Split(expr, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			partDays, partErr := parseDateExpr(part, now)
+			if partErr != nil {
+				return nil, partErr
+			}
+			days = append(days, partDays...)
+		}
+	default:
+		days, err = parseNamedRange(expr, today)
+		if err == nil && days == nil {
+			var day time.Time
+			day, err = parseSingleDate(expr, today)
+			days = []time.Time{day}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days = uniqueSortedDays(days)
+	if len(days) == 0 {
+		return nil, fmt.Errorf("date %q matches no days", input)
+	}
+	if len(days) > maxDateSpan {
+		return nil, fmt.Errorf("date %q spans %d days (max %d)", input, len(days), maxDateSpan)
+	}
+	return days, nil
+}
+
+func parseDateSpan(expr string, today time.Time) ([]time.Time, error) {
+	parts := strings.SplitN(expr, "..", 2)
+	start, err := parseSingleDate(strings.TrimSpace(parts[0]), today)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseSingleDate(strings.TrimSpace(parts[1]), today)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("date range %q ends before it starts", expr)
+	}
+	if span := int(math.Round(end.Sub(start).Hours()/24)) + 1; span > maxDateSpan {
+		return nil, fmt.Errorf("date range %q spans %d days (max %d)", expr, span, maxDateSpan)
+	}
+	return daysBetween(start, end), nil
+}
+
+// parseNamedRange handles the multi-day keywords. It returns nil days when
+// the expression is not one of them.
+func parseNamedRange(expr string, today time.Time) ([]time.Time, error) {
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	switch expr {
+	case "this week":
+		return daysBetween(today, monday.AddDate(0, 0, 6)), nil
+	case "next week":
+		return daysBetween(monday.AddDate(0, 0, 7), monday.AddDate(0, 0, 13)), nil
+	case "weekend", "this weekend":
+		return nextWeekendDates(today), nil
+	case "next weekend":
+		weekend := nextWeekendDates(today)
+		return []time.Time{weekend[0].AddDate(0, 0, 7), weekend[1].AddDate(0, 0, 7)}, nil
+	}
+	return nil, nil
+}
+
+func parseSingleDate(expr string, today time.Time) (time.Time, error) {
+	switch expr {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if strings.HasPrefix(expr, "+") || strings.HasPrefix(expr, "-") {
+		return parseRelativeDate(expr, today)
+	}
+
+	if weekday, ok := weekdayNames[expr]; ok {
+		return nextWeekday(today, weekday, false), nil
+	}
+	if rest, ok := strings.CutPrefix(expr, "this "); ok {
+		if weekday, ok := weekdayNames[rest]; ok {
+			return nextWeekday(today, weekday, false), nil
+		}
+	}
+	if rest, ok := strings.CutPrefix(expr, "next "); ok {
+		if weekday, ok := weekdayNames[rest]; ok {
+			return nextWeekday(today, weekday, true), nil
+		}
+	}
+
+	parsed, err := time.ParseInLocation("2006-01-02", expr, today.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD, today, tomorrow, a weekday, +Nd or a range)", expr)
+	}
+	return parsed, nil
+}
+
+// parseRelativeDate handles offsets such as "+3d", "-1d" and "+2w".
+func parseRelativeDate(expr string, today time.Time) (time.Time, error) {
+	unit := expr[len(expr)-1]
+	days := 1
+	switch unit {
+	case 'd':
+	case 'w':
+		days = 7
+	default:
+		return time.Time{}, fmt.Errorf("invalid date offset %q (expected e.g. +3d or +1w)", expr)
+	}
+	count, err := strconv.Atoi(expr[:len(expr)-1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date offset %q (expected e.g. +3d or +1w)", expr)
+	}
+	return today.AddDate(0, 0, count*days), nil
+}
+
+// parseEveryWeekday handles "saturday in november", "sat,sun in nov 2026" and
+// "sat and sun in december". A month without a year is the next occurrence of
+// that month, counting the current one.
+func parseEveryWeekday(expr string, today time.Time) ([]time.Time, error) {
+	weekdaysPart, monthPart, ok := strings.Cut(expr, " in ")
+	if !ok {
+		return nil, fmt.Errorf("invalid date %q (expected e.g. every saturday in november)", "every "+expr)
+	}
+
+	weekdays := map[time.Weekday]bool{}
+	for _, name := range strings.FieldsFunc(weekdaysPart, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if name == "and" {
+			continue
+		}
+		weekday, ok := weekdayNames[strings.TrimSuffix(name, "s")]
+		if !ok {
+			weekday, ok = weekdayNames[name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		weekdays[weekday] = true
+	}
+	if len(weekdays) == 0 {
+		return nil, fmt.Errorf("invalid date %q (expected e.g. every saturday in november)", "every "+expr)
+	}
+
+	fields := strings.Fields(monthPart)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid month %q", monthPart)
+	}
+	month, ok := parseMonth(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("unknown month %q", fields[0])
+	}
+	year := today.Year()
+	if month < today.Month() {
+		year++
+	}
+	if len(fields) == 2 {
+		parsedYear, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid year %q", fields[1])
+		}
+		year = parsedYear
+	}
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, today.Location())
+	days := []time.Time{}
+	for day := first; day.Month() == month; day = day.AddDate(0, 0, 1) {
+		if weekdays[day.Weekday()] {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func parseMonth(input string) (time.Month, bool) {
+	for month := time.January; month <= time.December; month++ {
+		name := strings.ToLower(month.String())
+		if input == name || (len(input) >= 3 && strings.HasPrefix(name, input)) {
+			return month, true
+		}
+	}
+	return 0, false
+}
+
+// nextWeekday returns the next given weekday on or after today, or strictly
+// after today when strict is set.
+func nextWeekday(today time.Time, weekday time.Weekday, strict bool) time.Time {
+	offset := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if strict && offset == 0 {
+		offset = 7
+	}
+	return today.AddDate(0, 0, offset)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func daysBetween(start, end time.Time) []time.Time {
+	days := []time.Time{}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+func uniqueSortedDays(days []time.Time) []time.Time {
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+	unique := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		if len(unique) > 0 && unique[len(unique)-1].Equal(day) {
+			continue
+		}
+		unique = append(unique, day)
+	}
+	return unique
+}
+
+func formatDays(days []time.Time) []string {
+	formatted := make([]string, 0, len(days))
+	for _, day := range days {
+		formatted = append(formatted, day.Format("2006-01-02"))
+	}
+	return formatted
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateExpr(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Skip("timezone data unavailable:", err)
+	}
+	// A Wednesday, the week summer time ends (Sunday 2026-10-25).
+	now := time.Date(2026, 10, 21, 10, 0, 0, 0, amsterdam)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "today", want: "2026-10-21"},
+		{input: "tomorrow", want: "2026-10-22"},
+		{input: "yesterday", want: "2026-10-20"},
+		{input: "sat", want: "2026-10-24"},
+		{input: "  Next   Saturday ", want: "2026-10-24"},
+		{input: "wednesday", want: "2026-10-21"},
+		{input: "this wed", want: "2026-10-21"},
+		{input: "next wed", want: "2026-10-28"},
+		{input: "+3d", want: "2026-10-24"},
+		{input: "-1w", want: "2026-10-14"},
+		{input: "+2w", want: "2026-11-04"},
+		{input: "2026-12-31", want: "2026-12-31"},
+		{input: "this week", want: "2026-10-21,2026-10-22,2026-10-23,2026-10-24,2026-10-25"},
+		{input: "next week", want: "2026-10-26,2026-10-27,2026-10-28,2026-10-29,2026-10-30,2026-10-31,2026-11-01"},
+		{input: "weekend", want: "2026-10-24,2026-10-25"},
+		{input: "next weekend", want: "2026-10-31,2026-11-01"},
+		{input: "sat,sun", want: "2026-10-24,2026-10-25"},
+		{input: "sun, sat, sat", want: "2026-10-24,2026-10-25"},
+		{input: "2026-10-20..2026-10-22", want: "2026-10-20,2026-10-21,2026-10-22"},
+		{input: "today..+2d", want: "2026-10-21,2026-10-22,2026-10-23"},
+		{input: "every saturday in november", want: "2026-11-07,2026-11-14,2026-11-21,2026-11-28"},
+		{input: "every sat and sun in dec 2026", want: "2026-12-05,2026-12-06,2026-12-12,2026-12-13,2026-12-19,2026-12-20,2026-12-26,2026-12-27"},
+		{input: "every sat,sun in nov", want: "2026-11-01,2026-11-07,2026-11-08,2026-11-14,2026-11-15,2026-11-21,2026-11-22,2026-11-28,2026-11-29"},
+		{input: "every sat in sep", want: "2027-09-04,2027-09-11,2027-09-18,2027-09-25"},
+	}
+	for _, tt := range tests {
+		days, err := parseDateExpr(tt.input, now)
+		if err != nil {
+			t.Errorf("parseDateExpr(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got := strings.Join(formatDays(days), ","); got != tt.want {
+			t.Errorf("parseDateExpr(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+		for _, day := range days {
+			if day.Hour() != 0 || day.Minute() != 0 || day.Location() != amsterdam {
+				t.Errorf("parseDateExpr(%q) day %v is not local midnight", tt.input, day)
+			}
+		}
+	}
+}
+
+func TestParseDateExprInvalid(t *testing.T) {
+	now := time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC)
+	for _, input := range []string{
+		"",
+		"someday",
+		"+3x",
+		"+d",
+		"2026-13-01",
+		"2026-10-22..2026-10-20",
+		"2026-01-01..2026-12-31",
+		"every sat",
+		"every funday in nov",
+		"every sat in smarch",
+		"every sat in nov twenty",
+	} {
+		if days, err := parseDateExpr(input, now); err == nil {
+			t.Errorf("parseDateExpr(%q) = %v, want error", input, formatDays(days))
+		}
+	}
+}
+
+func TestNextWeekday(t *testing.T) {
+	saturday := time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		weekday time.Weekday
+		strict  bool
+		want    time.Time
+	}{
+		{weekday: time.Saturday, strict: false, want: saturday},
+		{weekday: time.Saturday, strict: true, want: saturday.AddDate(0, 0, 7)},
+		{weekday: time.Sunday, strict: true, want: saturday.AddDate(0, 0, 1)},
+		{weekday: time.Friday, strict: false, want: saturday.AddDate(0, 0, 6)},
+	}
+	for _, tt := range tests {
+		if got := nextWeekday(saturday, tt.weekday, tt.strict); !got.Equal(tt.want) {
+			t.Errorf("nextWeekday(sat, %v, %v) = %v, want %v", tt.weekday, tt.strict, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"padel-cli/api"
+)
+
+// RoutingConfig selects an optional travel time provider. Without one only
+// straight-line distances are shown.
+type RoutingConfig struct {
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+	Profile  string `json:"profile"`
+}
+
+func newRouter(conf RoutingConfig) (api.Router, error) {
+	switch strings.ToLower(strings.TrimSpace(conf.Provider)) {
+	case "":
+		return nil, nil
+	case "osrm":
+		if conf.URL == "" {
+			return nil, fmt.Errorf("routing.url is required for the osrm provider")
+		}
+		return api.NewOSRMRouter(conf.URL, conf.Profile), nil
+	default:
+		return nil, fmt.Errorf("unknown routing provider %q (expected osrm)", conf.Provider)
+	}
+}
+
+// distanceMeter measures how far clubs are from a search origin. Travel
+// times are best effort: after the first routing error only distances are
+// reported.
+type distanceMeter struct {
+	origin api.Coordinate
+	router api.Router
+}
+
+func newDistanceMeter(lat, lon float64) (*distanceMeter, error) {
+	router, err := newRouter(cfg.Routing)
+	if err != nil {
+		return nil, err
+	}
+	return &distanceMeter{origin: api.Coordinate{Lat: lat, Lon: lon}, router: router}, nil
+}
+
+// distanceKm returns the straight-line distance to a club, or nil when the
+// club has no coordinates. A club next door is a known 0 km, not unknown.
+func (m *distanceMeter) distanceKm(to api.Coordinate) *float64 {
+	if m == nil || (to.Lat == 0 && to.Lon == 0) {
+		return nil
+	}
+	km := roundCents(api.HaversineKm(m.origin, to))
+	return &km
+}
+
+// withinDistance reports whether a club is no further than maxKm, which
+// (when set) also drops clubs whose distance is unknown.
+func withinDistance(km *float64, maxKm float64) bool {
+	return maxKm <= 0 || (km != nil && *km <= maxKm)
+}
+
+func (m *distanceMeter) travelMinutes(ctx context.Context, to api.Coordinate) int {
+	if m == nil || m.router == nil || (to.Lat == 0 && to.Lon == 0) {
+		return 0
+	}
+	route, err := m.router.Route(ctx, m.origin, to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: travel time unavailable: %v\n", err)
+		m.router = nil
+		return 0
+	}
+	return int(math.Ceil(route.Duration.Minutes()))
+}
+
+func formatDistance(km *float64) string {
+	if km == nil {
+		return ""
+	}
+	if *km < 1 {
+		return fmt.Sprintf("%.0f m", *km*1000)
+	}
+	return fmt.Sprintf("%.1f km", *km)
+}
+
+func formatTravel(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
+
+// compareDistance orders by distance with unknown distances last.
+func compareDistance(left, right *float64) int {
+	switch {
+	case left == nil && right == nil:
+		return 0
+	case left == nil:
+		return 1
+	case right == nil:
+		return -1
+	}
+	return cmp.Compare(*left, *right)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"padel-cli/api"
+)
+
+func TestDistanceNextDoorIsKnown(t *testing.T) {
+	origin := api.Coordinate{Lat: 51.9244, Lon: 4.4777}
+	meter := &distanceMeter{origin: origin}
+
+	nextDoor := meter.distanceKm(api.Coordinate{Lat: 51.92441, Lon: 4.47771})
+	if nextDoor == nil || *nextDoor != 0 {
+		t.Fatalf("distanceKm next door = %v, want a known 0", nextDoor)
+	}
+	if got := formatDistance(nextDoor); got != "0 m" {
+		t.Errorf("formatDistance(0) = %q, want %q", got, "0 m")
+	}
+	if got := meter.distanceKm(api.Coordinate{}); got != nil {
+		t.Errorf("distanceKm without coordinates = %v, want nil", *got)
+	}
+	if got := formatDistance(nil); got != "" {
+		t.Errorf("formatDistance(nil) = %q, want empty", got)
+	}
+
+	data, err := json.Marshal(ClubSummary{ID: "c1", DistanceKm: nextDoor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"c1","name":"","address":"","distance_km":0}`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
+
+func TestCompareDistanceSortsUnknownLast(t *testing.T) {
+	km := func(v float64) *float64 { return &v }
+	clubs := []ClubSummary{
+		{Name: "unknown", DistanceKm: nil},
+		{Name: "far", DistanceKm: km(12.5)},
+		{Name: "next door", DistanceKm: km(0)},
+		{Name: "near", DistanceKm: km(0.8)},
+	}
+	slices.SortStableFunc(clubs, func(a, b ClubSummary) int {
+		return compareDistance(a.DistanceKm, b.DistanceKm)
+	})
+	got := []string{}
+	for _, club := range clubs {
+		got = append(got, club.Name)
+	}
+	if want := []string{"next door", "near", "far", "unknown"}; !slices.Equal(got, want) {
+		t.Errorf("sorted %v, want %v", got, want)
+	}
+}
+
+func TestWithinDistance(t *testing.T) {
+	km := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name  string
+		km    *float64
+		maxKm float64
+		want  bool
+	}{
+		{name: "no limit, unknown", km: nil, maxKm: 0, want: true},
+		{name: "limit, unknown", km: nil, maxKm: 10, want: false},
+		{name: "next door", km: km(0), maxKm: 10, want: true},
+		{name: "at the limit", km: km(10), maxKm: 10, want: true},
+		{name: "past the limit", km: km(10.1), maxKm: 10, want: false},
+	}
+	for _, tt := range tests {
+		if got := withinDistance(tt.km, tt.maxKm); got != tt.want {
+			t.Errorf("%s: withinDistance = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+func bookingsExportCmd() *cobra.Command {
+	var format string
+	var file string
+	var from string
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export bookings as csv, json or a calendar file",
+		Long:  "Export bookings as csv or json (readable back with 'bookings import'), or as an iCalendar (RFC 5545) file. Calendar event UIDs are derived from booking IDs, so importing the file again updates events instead of duplicating them.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format = strings.ToLower(strings.TrimSpace(format))
+			switch format {
+			case "csv", "json", "ics":
+			default:
+				return fmt.Errorf("unsupported export format %q (expected csv, json or ics)", format)
+			}
+
+			filter := storage.BookingFilter{}
+			if from != "" {
+				date, err := parseDateInput(from)
+				if err != nil {
+					return err
+				}
+				filter.From = date.Format("2006-01-02")
+			}
+			if to != "" {
+				date, err := parseDateInput(to)
+				if err != nil {
+					return err
+				}
+				filter.To = date.Format("2006-01-02")
+			}
+
+			bookings, err := loadBookings(filter)
+			if err != nil {
+				return err
+			}
+
+			return writeExport(file, func(w io.Writer) error {
+				switch format {
+				case "csv":
+					return writeBookingsCSV(w, bookings)
+				case "json":
+					encoder := json.NewEncoder(w)
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(bookings)
+				}
+				venueByID, venueByAlias := buildVenueLookups()
+				return writeICS(w, bookings, venueByID, venueByAlias)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "csv", "Export format (csv|json|ics)")
+	cmd.Flags().StringVar(&file, "file", "", "Write to this file instead of stdout")
+	cmd.Flags().StringVar(&from, "from", "", "Only export bookings on/after this date")
+	cmd.Flags().StringVar(&to, "to", "", "Only export bookings on/before this date")
+	return cmd
+}
+
+// loadBookings lists bookings matching filter with their timezone and UTC
+// start filled in from the saved venues.
+func loadBookings(filter storage.BookingFilter) ([]storage.Booking, error) {
+	db, err := storage.OpenBookingsDB()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// Rows stored before start_utc existed get one from their venue's
+	// timezone, so that past and upcoming can be decided in SQL.
+	venueByID, venueByAlias := buildVenueLookups()
+	if _, err := storage.BackfillStartUTC(db, func(booking *storage.Booking) {
+		ensureBookingTimezone(booking, venueByID, venueByAlias)
+	}); err != nil {
+		return nil, err
+	}
+
+	bookings, err := storage.ListBookings(db, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bookings {
+		ensureBookingTimezone(&bookings[i], venueByID, venueByAlias)
+	}
+	return bookings, nil
+}
+
+func writeExport(path string, write func(io.Writer) error) error {
+	if path == "" || path == "-" {
+		return write(os.Stdout)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// bookingColumns are the csv columns written by export and recognised by
+// import without a mapping. They match the json field names.
+var bookingColumns = []string{
+	"id", "venue_alias", "venue_name", "venue_id", "court", "date", "time", "start_utc",
+	"venue_timezone", "duration", "price", "currency", "booked_at", "source",
+}
+
+func writeBookingsCSV(w io.Writer, bookings []storage.Booking) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(bookingColumns); err != nil {
+		return err
+	}
+	for _, booking := range bookings {
+		if err := writer.Write([]string{
+			booking.ID,
+			booking.VenueAlias,
+			booking.VenueName,
+			booking.VenueID,
+			booking.Court,
+			booking.Date,
+			booking.Time,
+			booking.StartUTC,
+			booking.VenueTimezone,
+			strconv.Itoa(booking.Duration),
+			strconv.FormatFloat(booking.Price, 'f', 2, 64),
+			booking.Currency,
+			booking.BookedAt,
+			booking.Source,
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
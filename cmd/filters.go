@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// slotFilter holds the court and slot rules shared by search, availability
+// and book.
+type slotFilter struct {
+	ShowOutdoor bool
+	ShowAll     bool
+	Duration    int
+	Size        string
+	Feature     string
+	TimeRange   string
+	TimeMode    string
+	Windows     []timeWindow
+	Rules       *storage.CourtRules
+}
+
+// timeWindow is a daily clock window in minutes after midnight. End is always
+// after Start; windows that cross midnight have End beyond 24:00.
+type timeWindow struct {
+	Start int
+	End   int
+}
+
+const (
+	timeModeStart = "start"
+	timeModeFull  = "full"
+	minutesPerDay = 24 * 60
+)
+
+func addTimeWindowFlags(cmd *cobra.Command, filter *slotFilter) {
+	cmd.Flags().StringVar(&filter.TimeRange, "time", "", "Time windows (HH:MM-HH:MM, comma-separated, may cross midnight)")
+	addTimeModeFlag(cmd, filter)
+}
+
+func addTimeModeFlag(cmd *cobra.Command, filter *slotFilter) {
+	cmd.Flags().StringVar(&filter.TimeMode, "time-mode", timeModeStart, "Match slots that start within the window (start) or fit entirely inside it (full)")
+}
+
+func addSlotFilterFlags(cmd *cobra.Command, filter *slotFilter) {
+	cmd.Flags().IntVar(&filter.Duration, "duration", 0, "Only show slots of this length in minutes (e.g. 60, 90, 120)")
+	cmd.Flags().StringVar(&filter.Size, "size", "", "Only show courts of this size (double|single)")
+	cmd.Flags().StringVar(&filter.Feature, "feature", "", "Only show courts with this feature (e.g. panoramic)")
+}
+
+func (f *slotFilter) validate() error {
+	if f.ShowOutdoor && f.ShowAll {
+		return fmt.Errorf("use either --outdoor or --all, not both")
+	}
+	if f.Duration < 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+	f.Size = strings.ToLower(strings.TrimSpace(f.Size))
+	if f.Size != "" && f.Size != "double" && f.Size != "single" {
+		return fmt.Errorf("invalid --size %q (expected double|single)", f.Size)
+	}
+	f.Feature = strings.ToLower(strings.TrimSpace(f.Feature))
+
+	switch strings.ToLower(strings.TrimSpace(f.TimeMode)) {
+	case "", timeModeStart, "starts-within":
+		f.TimeMode = timeModeStart
+	case timeModeFull, "fully-within":
+		f.TimeMode = timeModeFull
+	default:
+		return fmt.Errorf("invalid --time-mode %q (expected start|full)", f.TimeMode)
+	}
+	f.Windows = nil
+	if strings.TrimSpace(f.TimeRange) != "" {
+		windows, err := parseTimeWindows(f.TimeRange)
+		if err != nil {
+			return err
+		}
+		f.Windows = windows
+	}
+	return nil
+}
+
+// parseTimeWindows parses "07:00-09:00,18:00-22:00". A window whose end is
+// before its start crosses midnight, and a bare "HH:MM" matches that exact
+// start time.
+func parseTimeWindows(input string) ([]timeWindow, error) {
+	windows := []timeWindow{}
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.Split(part, "-")
+		if len(bounds) == 1 {
+			minutes, err := parseClock(strings.TrimSpace(bounds[0]))
+			if err != nil {
+				return nil, err
+			}
+			windows = append(windows, timeWindow{Start: minutes, End: minutes})
+			continue
+		}
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid time range %q (expected HH:MM-HH:MM)", part)
+		}
+		start, err := parseClock(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseWindowEnd(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return nil, err
+		}
+		if end == start {
+			return nil, fmt.Errorf("time range %q is empty", part)
+		}
+		if end < start {
+			end += minutesPerDay
+		}
+		windows = append(windows, timeWindow{Start: start, End: end})
+	}
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("invalid time range %q (expected HH:MM-HH:MM)", input)
+	}
+	return windows, nil
+}
+
+func parseWindowEnd(input string) (int, error) {
+	if input == "24:00" {
+		return minutesPerDay, nil
+	}
+	return parseClock(input)
+}
+
+// crossesMidnight reports whether a window runs into the next day, so that
+// the next morning's slots have to be fetched too.
+func (f slotFilter) crossesMidnight() bool {
+	return f.lastWindowEnd() > minutesPerDay
+}
+
+func (f slotFilter) lastWindowEnd() int {
+	end := 0
+	for _, window := range f.Windows {
+		end = max(end, window.End)
+	}
+	return end
+}
+
+// slotStart returns when a slot on localDate at localTime starts, in minutes
+// after midnight of targetDate. Slots on the next day count from 24:00 when
+// a window crosses midnight; slots on other days do not belong to
+// targetDate. An unknown date is taken to be targetDate.
+func (f slotFilter) slotStart(localDate, localTime, targetDate string) (int, bool) {
+	minutes, err := slotMinutes(localTime)
+	if err != nil {
+		return 0, false
+	}
+	if targetDate == "" || localDate == "" || localDate == targetDate {
+		return minutes, true
+	}
+	if f.crossesMidnight() && localDate == nextDate(targetDate) {
+		return minutes + minutesPerDay, true
+	}
+	return 0, false
+}
+
+func nextDate(date string) string {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	return parsed.AddDate(0, 0, 1).Format("2006-01-02")
+}
+
+// slotClock formats minutes after midnight as HH:MM, running past 24:00
+// for the next morning of a window that crosses midnight.
+func slotClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// matchesTime reports whether a slot starting the given number of minutes
+// after midnight of the target day fits one of the windows under the
+// filter's time mode. Slots on the next morning are past 24:00.
+func (f slotFilter) matchesTime(minutes, duration int) bool {
+	if len(f.Windows) == 0 {
+		return true
+	}
+	for _, window := range f.Windows {
+		if window.Start == window.End {
+			if minutes == window.Start {
+				return true
+			}
+			continue
+		}
+		if minutes < window.Start {
+			continue
+		}
+		if f.TimeMode == timeModeFull {
+			if minutes+duration <= window.End {
+				return true
+			}
+			continue
+		}
+		if minutes <= window.End {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesResource reports whether a court passes the indoor/outdoor, size and
+// feature rules. Courts without resource info count as indoor with unknown
+// size and feature.
+func (f slotFilter) matchesResource(resource api.Resource, hasInfo bool) bool {
+	isIndoor := true
+	if hasInfo {
+		isIndoor = resource.IsIndoor()
+	}
+	if !f.ShowAll {
+		if f.ShowOutdoor && isIndoor {
+			return false
+		}
+		if !f.ShowOutdoor && !isIndoor {
+			return false
+		}
+	}
+	if f.Size != "" && !strings.EqualFold(resource.Properties.ResourceSize, f.Size) {
+		return false
+	}
+	if f.Feature != "" && !strings.EqualFold(resource.Properties.ResourceFeature, f.Feature) {
+		return false
+	}
+	return true
+}
+
+func (f slotFilter) matchesSlot(slot api.Slot) bool {
+	return f.Duration <= 0 || slot.Duration == f.Duration
+}
+
+// allowsCourt reports whether the venue's court rules let a court through.
+func (f slotFilter) allowsCourt(name string) bool {
+	if f.Rules == nil {
+		return true
+	}
+	for _, pattern := range f.Rules.Exclude {
+		if courtPatternMatches(pattern, name) {
+			return false
+		}
+	}
+	if len(f.Rules.Only) == 0 {
+		return true
+	}
+	for _, pattern := range f.Rules.Only {
+		if courtPatternMatches(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// courtRank is the position of a court in the venue's preference list.
+// Courts that are not listed rank after all listed ones.
+func (f slotFilter) courtRank(name string) int {
+	if f.Rules == nil {
+		return 0
+	}
+	for idx, pattern := range f.Rules.Prefer {
+		if courtPatternMatches(pattern, name) {
+			return idx
+		}
+	}
+	return len(f.Rules.Prefer)
+}
+
+// courtPatternMatches matches a court name case-insensitively. A pattern
+// without glob characters matches any name containing it, so "Binnen"
+// covers "Binnen 1" and "Binnen 2"; in a glob, * also spans slashes.
+func courtPatternMatches(pattern, name string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	name = strings.ToLower(strings.TrimSpace(name))
+	if pattern == "" {
+		return false
+	}
+	if pattern == name {
+		return true
+	}
+	if !strings.ContainsAny(pattern, `*?[\`) {
+		return strings.Contains(name, pattern)
+	}
+	// path.Match stops * at "/", which court names use as an ordinary
+	// character ("Court 1/2"), so hide it from the matcher.
+	const slash = "\x00"
+	matched, err := path.Match(strings.ReplaceAll(pattern, "/", slash), strings.ReplaceAll(name, "/", slash))
+	return err == nil && matched
+}
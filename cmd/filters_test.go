@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"padel-cli/api"
+)
+
+func TestParseTimeWindows(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []timeWindow
+	}{
+		{input: "10:00-12:00", want: []timeWindow{{Start: 600, End: 720}}},
+		{input: "07:00-09:00, 18:00-22:00", want: []timeWindow{{Start: 420, End: 540}, {Start: 1080, End: 1320}}},
+		{input: "22:00-01:00", want: []timeWindow{{Start: 1320, End: 1500}}},
+		{input: "20:00-24:00", want: []timeWindow{{Start: 1200, End: 1440}}},
+		{input: "19:30", want: []timeWindow{{Start: 1170, End: 1170}}},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeWindows(tt.input)
+		if err != nil {
+			t.Errorf("parseTimeWindows(%q) error: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseTimeWindows(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", ",", "10:00-10:00", "10-12", "10:00-11:00-12:00", "25:00-26:00"} {
+		if got, err := parseTimeWindows(input); err == nil {
+			t.Errorf("parseTimeWindows(%q) = %v, want error", input, got)
+		}
+	}
+}
+
+func TestMatchesTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		windows  string
+		mode     string
+		minutes  int
+		duration int
+		want     bool
+	}{
+		{name: "no window", minutes: 600, duration: 90, want: true},
+		{name: "start inside", windows: "10:00-12:00", mode: timeModeStart, minutes: 690, duration: 90, want: true},
+		{name: "start at end", windows: "10:00-12:00", mode: timeModeStart, minutes: 720, duration: 90, want: true},
+		{name: "start before", windows: "10:00-12:00", mode: timeModeStart, minutes: 570, duration: 90, want: false},
+		{name: "full fits", windows: "10:00-12:00", mode: timeModeFull, minutes: 630, duration: 90, want: true},
+		{name: "full overruns", windows: "10:00-12:00", mode: timeModeFull, minutes: 660, duration: 90, want: false},
+		{name: "second window", windows: "07:00-09:00,18:00-22:00", mode: timeModeStart, minutes: 1140, duration: 60, want: true},
+		{name: "exact time", windows: "19:30", mode: timeModeStart, minutes: 1170, duration: 90, want: true},
+		{name: "not exact time", windows: "19:30", mode: timeModeStart, minutes: 1200, duration: 90, want: false},
+
+		// 22:00-01:00 covers the evening of the target day and the first
+		// hour of the next day, which counts from 24:00.
+		{name: "overnight evening", windows: "22:00-01:00", mode: timeModeStart, minutes: 1320, duration: 90, want: true},
+		{name: "overnight next morning", windows: "22:00-01:00", mode: timeModeStart, minutes: 1440 + 30, duration: 60, want: true},
+		{name: "overnight same morning", windows: "22:00-01:00", mode: timeModeStart, minutes: 0, duration: 60, want: false},
+		{name: "overnight same morning half past", windows: "22:00-01:00", mode: timeModeStart, minutes: 30, duration: 60, want: false},
+		{name: "overnight full fits", windows: "22:00-01:00", mode: timeModeFull, minutes: 1380, duration: 120, want: true},
+		{name: "overnight full ends at window end", windows: "22:00-01:00", mode: timeModeFull, minutes: 1410, duration: 90, want: true},
+		{name: "overnight full overruns", windows: "22:00-01:00", mode: timeModeFull, minutes: 1440, duration: 90, want: false},
+	}
+	for _, tt := range tests {
+		filter := slotFilter{TimeRange: tt.windows, TimeMode: tt.mode}
+		if err := filter.validate(); err != nil {
+			t.Fatalf("%s: validate: %v", tt.name, err)
+		}
+		if got := filter.matchesTime(tt.minutes, tt.duration); got != tt.want {
+			t.Errorf("%s: matchesTime(%d, %d) = %v, want %v", tt.name, tt.minutes, tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestSlotStart(t *testing.T) {
+	overnight := slotFilter{TimeRange: "22:00-01:00"}
+	evening := slotFilter{TimeRange: "18:00-22:00"}
+	for _, filter := range []*slotFilter{&overnight, &evening} {
+		if err := filter.validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		filter    slotFilter
+		target    string
+		localDate string
+		localTime string
+		want      int
+		wantOK    bool
+	}{
+		{name: "same day", filter: evening, localDate: "2026-10-24", localTime: "19:00", want: 1140, wantOK: true},
+		{name: "unknown date", filter: evening, localDate: "", localTime: "19:00", want: 1140, wantOK: true},
+		{name: "next day without overnight window", filter: evening, localDate: "2026-10-25", localTime: "00:30", wantOK: false},
+		{name: "next day with overnight window", filter: overnight, localDate: "2026-10-25", localTime: "00:30", want: 1470, wantOK: true},
+		{name: "previous day", filter: overnight, localDate: "2026-10-23", localTime: "23:00", wantOK: false},
+		{name: "month end", filter: overnight, target: "2026-10-31", localDate: "2026-11-01", localTime: "00:00", want: 1440, wantOK: true},
+	}
+	for _, tt := range tests {
+		target := tt.target
+		if target == "" {
+			target = "2026-10-24"
+		}
+		got, ok := tt.filter.slotStart(tt.localDate, tt.localTime, target)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("%s: slotStart = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSlotClockRoundTrip(t *testing.T) {
+	for _, minutes := range []int{0, 570, 1439, 1440, 1470, 1500} {
+		label := slotClock(minutes)
+		got, err := slotMinutes(label)
+		if err != nil || got != minutes {
+			t.Errorf("slotMinutes(slotClock(%d) = %q) = %d, %v", minutes, label, got, err)
+		}
+	}
+	if got := slotClock(1470); got != "24:30" {
+		t.Errorf("slotClock(1470) = %q, want 24:30", got)
+	}
+}
+
+func TestOvernightWindowUsesNextMorning(t *testing.T) {
+	filter := slotFilter{ShowAll: true, TimeRange: "22:00-01:00"}
+	if err := filter.validate(); err != nil {
+		t.Fatal(err)
+	}
+	// Saturday 2026-10-24 in Amsterdam is UTC+2. The day fetch holds the
+	// early hours of Saturday, which are the night before; the next
+	// morning fetch holds Sunday 00:30.
+	availability := []api.AvailabilityResource{
+		{ResourceID: "c1", StartDate: "2026-10-23", Slots: []api.Slot{{StartTime: "22:00:00", Duration: 60}}},
+		{ResourceID: "c1", StartDate: "2026-10-24", Slots: []api.Slot{{StartTime: "20:00:00", Duration: 90}}},
+		{ResourceID: "c1", StartDate: "2026-10-24", Slots: []api.Slot{{StartTime: "22:30:00", Duration: 60}}},
+	}
+	resources := map[string]api.Resource{"c1": {ResourceID: "c1", Name: "Court 1"}}
+
+	slots := filterAvailabilityWithResources(availability, resources, "2026-10-24", "Europe/Amsterdam", filter)
+	got := []string{}
+	for _, slot := range slots {
+		got = append(got, slot.Time+" "+slot.StartUTC)
+	}
+	want := []string{"22:00 2026-10-24T20:00:00Z", "24:30 2026-10-24T22:30:00Z"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterAvailabilityWithResources = %v, want %v", got, want)
+	}
+
+	match, err := selectSlot(availability, resources, "2026-10-24", "Europe/Amsterdam", filter, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match.Slot.StartTime != "20:00:00" {
+		t.Errorf("selectSlot picked %s UTC, want the 22:00 local slot", match.Slot.StartTime)
+	}
+}
+
+func TestCourtPatternMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "Padel 6", name: "padel 6", want: true},
+		{pattern: "Binnen", name: "Binnen 1", want: true},
+		{pattern: "binnen", name: "Court 2 (Binnen)", want: true},
+		{pattern: "Binnen", name: "Buiten 1", want: false},
+		{pattern: "*buiten*", name: "Court 3 Buiten", want: true},
+		{pattern: "Padel ?", name: "Padel 7", want: true},
+		{pattern: "Padel ?", name: "Padel 10", want: false},
+		{pattern: "Court [12]", name: "Court 2", want: true},
+		{pattern: "Court [12]", name: "Court 3", want: false},
+		{pattern: "Court *", name: "Court 1/2", want: true},
+		{pattern: "*/2", name: "Court 1/2", want: true},
+		{pattern: "Court 1/2", name: "court 1/2", want: true},
+		{pattern: "", name: "Court 1", want: false},
+		{pattern: "[", name: "Court 1", want: false},
+	}
+	for _, tt := range tests {
+		if got := courtPatternMatches(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("courtPatternMatches(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
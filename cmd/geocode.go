@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+)
+
+// GeocoderConfig selects how place names are turned into coordinates.
+// Provider "none" never goes online and only answers from the cache.
+type GeocoderConfig struct {
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+	Email    string `json:"email"`
+}
+
+var geocoder api.Geocoder
+
+// cachedGeocoder answers from the geocode cache in the config dir and only
+// asks next (if any) for places it has not seen before.
+type cachedGeocoder struct {
+	next api.Geocoder
+}
+
+func (g cachedGeocoder) Geocode(ctx context.Context, query string) (api.Coordinate, error) {
+	cache, err := storage.LoadGeocodeCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring geocode cache: %v\n", err)
+		cache = map[string]storage.GeocodeEntry{}
+	}
+	key := storage.GeocodeKey(query)
+	if entry, ok := cache[key]; ok {
+		return api.Coordinate{Lat: entry.Lat, Lon: entry.Lon}, nil
+	}
+	if g.next == nil {
+		return api.Coordinate{}, fmt.Errorf("%q is not in the geocode cache and geocoding is disabled; use lat,lon or a saved location", query)
+	}
+
+	coord, err := g.next.Geocode(ctx, query)
+	if err != nil {
+		return api.Coordinate{}, fmt.Errorf("geocode %q: %w (offline? use lat,lon or a saved location)", query, err)
+	}
+	cache[key] = storage.GeocodeEntry{Lat: coord.Lat, Lon: coord.Lon, UpdatedAt: time.Now().UTC().Format(time.RFC3339)}
+	if err := storage.SaveGeocodeCache(cache); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not save geocode cache: %v\n", err)
+	}
+	return coord, nil
+}
+
+func newGeocoder(conf GeocoderConfig) (api.Geocoder, error) {
+	switch strings.ToLower(strings.TrimSpace(conf.Provider)) {
+	case "", "nominatim":
+		return cachedGeocoder{next: api.NewNominatimGeocoder(conf.URL, conf.Email)}, nil
+	case "none", "offline":
+		return cachedGeocoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown geocoder provider %q (expected nominatim|none)", conf.Provider)
+	}
+}
+
+// savedLocation looks up a named location ("home", "office") from config.
+func savedLocation(name string) (string, bool) {
+	needle := strings.ToLower(strings.TrimSpace(name))
+	for key, value := range cfg.Locations {
+		if strings.ToLower(key) == needle {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func geocode(ctx context.Context, query string) (float64, float64, error) {
+	if geocoder == nil {
+		created, err := newGeocoder(cfg.Geocoder)
+		if err != nil {
+			return 0, 0, err
+		}
+		geocoder = created
+	}
+	coord, err := geocoder.Geocode(ctx, query)
+	if err != nil {
+		return 0, 0, err
+	}
+	return coord.Lat, coord.Lon, nil
+}
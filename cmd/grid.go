@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AvailabilityGrid is availability over several days pivoted into a matrix:
+// one row per start time (per court when PerCourt is set) and one column per
+// date. Days keeps the underlying slots for json and line-oriented output.
+type AvailabilityGrid struct {
+	ClubID   string               `json:"club_id"`
+	ClubName string               `json:"club_name"`
+	Dates    []string             `json:"dates"`
+	PerCourt bool                 `json:"per_court"`
+	Courts   []string             `json:"courts"`
+	Rows     []AvailabilityRow    `json:"rows"`
+	Days     []AvailabilityOutput `json:"days"`
+}
+
+// AvailabilityRow holds the number of free courts for one start time on each
+// date of the grid, in the same order as AvailabilityGrid.Dates. In a
+// per-court grid the counts are 0 or 1.
+type AvailabilityRow struct {
+	Court string `json:"court,omitempty"`
+	Time  string `json:"time"`
+	Free  []int  `json:"free"`
+}
+
+const (
+	gridFree  = "✓"
+	gridTaken = "✗"
+	gridNone  = "-"
+)
+
+func buildAvailabilityGrid(outputs []AvailabilityOutput, perCourt bool) AvailabilityGrid {
+	grid := AvailabilityGrid{PerCourt: perCourt, Days: outputs}
+	if len(outputs) > 0 {
+		grid.ClubID = outputs[0].ClubID
+		grid.ClubName = outputs[0].ClubName
+	}
+
+	type rowKey struct {
+		court string
+		time  string
+	}
+	// A court counts once per start time, whatever durations it offers.
+	free := map[rowKey][]map[string]bool{}
+	courts := map[string]bool{}
+	for dayIdx, output := range outputs {
+		grid.Dates = append(grid.Dates, output.Date)
+		for _, slot := range output.Slots {
+			courts[slot.Court] = true
+			key := rowKey{time: slot.Time}
+			if perCourt {
+				key.court = slot.Court
+			}
+			if free[key] == nil {
+				free[key] = make([]map[string]bool, len(outputs))
+			}
+			if free[key][dayIdx] == nil {
+				free[key][dayIdx] = map[string]bool{}
+			}
+			free[key][dayIdx][slot.Court] = true
+		}
+	}
+
+	for court := range courts {
+		grid.Courts = append(grid.Courts, court)
+	}
+	sort.Strings(grid.Courts)
+
+	keys := make([]rowKey, 0, len(free))
+	for key := range free {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].court != keys[j].court {
+			return keys[i].court < keys[j].court
+		}
+		return keys[i].time < keys[j].time
+	})
+	for _, key := range keys {
+		row := AvailabilityRow{Court: key.court, Time: key.time, Free: make([]int, len(outputs))}
+		for dayIdx, courtsFree := range free[key] {
+			row.Free[dayIdx] = len(courtsFree)
+		}
+		grid.Rows = append(grid.Rows, row)
+	}
+	return grid
+}
+
+func availabilityGridSpec(grid AvailabilityGrid, marks bool) renderSpec {
+	return renderSpec{
+		Data: grid,
+		Records: func() []any {
+			records := []SlotRecord{}
+			for _, output := range grid.Days {
+				records = append(records, availabilityRecords(output)...)
+			}
+			return slotRecordValues(records)
+		},
+		Table: func() tableData {
+			return gridTable(grid, marks)
+		},
+		Text: func() error {
+			return renderAvailabilityGrid(grid, marks)
+		},
+	}
+}
+
+func gridTable(grid AvailabilityGrid, marks bool) tableData {
+	table := tableData{}
+	if grid.PerCourt {
+		table.Headers = append(table.Headers, "COURT")
+	}
+	table.Headers = append(table.Headers, "TIME")
+	table.Headers = append(table.Headers, gridDayHeaders(grid.Dates)...)
+	for _, row := range grid.Rows {
+		cells := []string{}
+		if grid.PerCourt {
+			cells = append(cells, row.Court)
+		}
+		cells = append(cells, row.Time)
+		for _, count := range row.Free {
+			cells = append(cells, gridCell(count, marks || grid.PerCourt))
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+	return table
+}
+
+func renderAvailabilityGrid(grid AvailabilityGrid, marks bool) error {
+	fmt.Printf("%s (%s)\n", grid.ClubName, grid.ClubID)
+	if len(grid.Dates) > 0 {
+		fmt.Printf("Dates: %s to %s\n", grid.Dates[0], grid.Dates[len(grid.Dates)-1])
+	}
+	if len(grid.Rows) == 0 {
+		fmt.Println("No available slots.")
+		return nil
+	}
+	if !grid.PerCourt {
+		return writeTable(os.Stdout, gridTable(grid, marks))
+	}
+
+	// One block per court reads better than a court column repeated on
+	// every row.
+	for idx, court := range grid.Courts {
+		courtGrid := grid
+		courtGrid.PerCourt = false
+		courtGrid.Rows = nil
+		for _, row := range grid.Rows {
+			if row.Court == court {
+				courtGrid.Rows = append(courtGrid.Rows, row)
+			}
+		}
+		if idx > 0 {
+			fmt.Println()
+		}
+		fmt.Println(court)
+		if err := writeTable(os.Stdout, gridTable(courtGrid, true)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func gridDayHeaders(dates []string) []string {
+	headers := make([]string, 0, len(dates))
+	for _, date := range dates {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			headers = append(headers, date)
+			continue
+		}
+		headers = append(headers, day.Format("Mon 02/01"))
+	}
+	return headers
+}
+
+func gridCell(count int, marks bool) string {
+	if marks {
+		if count > 0 {
+			return gridFree
+		}
+		return gridTaken
+	}
+	if count == 0 {
+		return gridNone
+	}
+	return strconv.Itoa(count)
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+
+	"github.com/spf13/cobra"
+)
+
+// SlotGroup is a set of free slots that are booked together: back-to-back
+// blocks on one court (consecutive) or the same block on several courts
+// (parallel).
+type SlotGroup struct {
+	Date        string             `json:"date"`
+	ClubID      string             `json:"club_id"`
+	ClubName    string             `json:"club_name"`
+	Kind        string             `json:"kind"`
+	Time        string             `json:"time"`
+	End         string             `json:"end"`
+	Duration    int                `json:"duration"`
+	Courts      []string           `json:"courts"`
+	PriceAmount float64            `json:"price_amount,omitempty"`
+	Currency    string             `json:"currency,omitempty"`
+	Slots       []AvailabilitySlot `json:"slots"`
+}
+
+const (
+	groupConsecutive = "consecutive"
+	groupParallel    = "parallel"
+	// maxGroupBlock is the longest block assumed when --duration is not set.
+	maxGroupBlock = 120
+)
+
+// groupOptions selects how many blocks make up a group. At most one of the
+// two counts is above 1.
+type groupOptions struct {
+	Consecutive int
+	Courts      int
+}
+
+func addGroupFlags(cmd *cobra.Command, opts *groupOptions) {
+	cmd.Flags().IntVar(&opts.Consecutive, "consecutive", 1, "Number of back-to-back blocks on the same court")
+	cmd.Flags().IntVar(&opts.Courts, "courts", 1, "Number of courts at the same time")
+}
+
+func (o groupOptions) validate() error {
+	if o.Consecutive < 1 || o.Courts < 1 {
+		return fmt.Errorf("--consecutive and --courts must be at least 1")
+	}
+	if o.Consecutive > 1 && o.Courts > 1 {
+		return fmt.Errorf("use either --consecutive or --courts, not both")
+	}
+	return nil
+}
+
+func (o groupOptions) enabled() bool {
+	return o.Consecutive > 1 || o.Courts > 1
+}
+
+// fetchFilter returns the filter to load slots with. Grouping needs every
+// block, including those after the time window, so the windows are applied to
+// whole groups instead. When a group starting in a window can run past
+// midnight, the fetch keeps one window reaching into the next morning so
+// those slots are loaded and matched too.
+func (o groupOptions) fetchFilter(filter slotFilter) slotFilter {
+	if !o.enabled() {
+		return filter
+	}
+	block := filter.Duration
+	if block <= 0 {
+		block = maxGroupBlock
+	}
+	end := filter.lastWindowEnd() + max(o.Consecutive-1, 0)*block
+	filter.Windows = nil
+	if end > minutesPerDay {
+		filter.TimeMode = timeModeStart
+		filter.Windows = []timeWindow{{Start: 0, End: end}}
+	}
+	return filter
+}
+
+func (o groupOptions) groups(slots []AvailabilitySlot, filter slotFilter) []SlotGroup {
+	if o.Consecutive > 1 {
+		return consecutiveGroups(slots, o.Consecutive, filter)
+	}
+	return parallelGroups(slots, o.Courts, filter)
+}
+
+type slotKey struct {
+	court    string
+	start    time.Time
+	duration int
+}
+
+// consecutiveGroups finds runs of count blocks of the same length on one
+// court, each starting when the previous one ends. Adjacency is checked on
+// the UTC start so runs across midnight and DST changes line up.
+func consecutiveGroups(slots []AvailabilitySlot, count int, filter slotFilter) []SlotGroup {
+	index := map[slotKey]AvailabilitySlot{}
+	for _, slot := range slots {
+		start, err := time.Parse(time.RFC3339, slot.StartUTC)
+		if err != nil {
+			continue
+		}
+		index[slotKey{court: slotResource(slot), start: start, duration: slot.Duration}] = slot
+	}
+
+	groups := []SlotGroup{}
+	for _, first := range slots {
+		start, err := time.Parse(time.RFC3339, first.StartUTC)
+		if err != nil || first.Duration <= 0 {
+			continue
+		}
+		run := []AvailabilitySlot{first}
+		next := start
+		for len(run) < count {
+			next = next.Add(time.Duration(first.Duration) * time.Minute)
+			slot, ok := index[slotKey{court: slotResource(first), start: next, duration: first.Duration}]
+			if !ok {
+				break
+			}
+			run = append(run, slot)
+		}
+		if len(run) < count {
+			continue
+		}
+		if group, ok := newSlotGroup(groupConsecutive, run, count*first.Duration, filter); ok {
+			groups = append(groups, group)
+		}
+	}
+	sortSlotGroups(groups)
+	return groups
+}
+
+// parallelGroups finds start times where at least count courts are free for
+// the same length, and takes the first count courts in the venue's court
+// preference order, then by name.
+func parallelGroups(slots []AvailabilitySlot, count int, filter slotFilter) []SlotGroup {
+	type blockKey struct {
+		start    string
+		duration int
+	}
+	byBlock := map[blockKey][]AvailabilitySlot{}
+	for _, slot := range slots {
+		if slot.StartUTC == "" {
+			continue
+		}
+		key := blockKey{start: slot.StartUTC, duration: slot.Duration}
+		byBlock[key] = append(byBlock[key], slot)
+	}
+
+	groups := []SlotGroup{}
+	for key, block := range byBlock {
+		seen := map[string]bool{}
+		courts := []AvailabilitySlot{}
+		for _, slot := range block {
+			if !seen[slotResource(slot)] {
+				seen[slotResource(slot)] = true
+				courts = append(courts, slot)
+			}
+		}
+		if len(courts) < count {
+			continue
+		}
+		sort.Slice(courts, func(i, j int) bool {
+			if left, right := filter.courtRank(courts[i].Court), filter.courtRank(courts[j].Court); left != right {
+				return left < right
+			}
+			return courts[i].Court < courts[j].Court
+		})
+		if group, ok := newSlotGroup(groupParallel, courts[:count], key.duration, filter); ok {
+			groups = append(groups, group)
+		}
+	}
+	sortSlotGroups(groups)
+	return groups
+}
+
+func newSlotGroup(kind string, slots []AvailabilitySlot, duration int, filter slotFilter) (SlotGroup, bool) {
+	minutes, err := slotMinutes(slots[0].Time)
+	if err != nil || !filter.matchesTime(minutes, duration) {
+		return SlotGroup{}, false
+	}
+	group := SlotGroup{
+		Kind:     kind,
+		Time:     slots[0].Time,
+		End:      clockLabel(minutes + duration),
+		Duration: duration,
+		Currency: slots[0].Currency,
+		Slots:    slots,
+	}
+	for _, slot := range slots {
+		if len(group.Courts) == 0 || kind == groupParallel {
+			group.Courts = append(group.Courts, slot.Court)
+		}
+		group.PriceAmount += slot.PriceAmount
+	}
+	group.PriceAmount = roundCents(group.PriceAmount)
+	return group, true
+}
+
+func sortSlotGroups(groups []SlotGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Slots[0].StartUTC != groups[j].Slots[0].StartUTC {
+			return groups[i].Slots[0].StartUTC < groups[j].Slots[0].StartUTC
+		}
+		return strings.Join(groups[i].Courts, ",") < strings.Join(groups[j].Courts, ",")
+	})
+}
+
+func slotResource(slot AvailabilitySlot) string {
+	if slot.ResourceID != "" {
+		return slot.ResourceID
+	}
+	return slot.Court
+}
+
+func clockLabel(minutes int) string {
+	minutes %= minutesPerDay
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// searchGroups turns per-club search results into slot groups.
+func searchGroups(results []SearchResult, opts groupOptions, filter slotFilter) []SlotGroup {
+	groups := []SlotGroup{}
+	for _, result := range results {
+		for _, club := range result.Clubs {
+			for _, group := range opts.groups(club.Slots, filter) {
+				group.Date = result.Date
+				group.ClubID = club.ClubID
+				group.ClubName = club.ClubName
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}
+
+func slotGroupsSpec(groups []SlotGroup) renderSpec {
+	return renderSpec{
+		Data: groups,
+		Records: func() []any {
+			values := make([]any, 0, len(groups))
+			for _, group := range groups {
+				values = append(values, group)
+			}
+			return values
+		},
+		Table: func() tableData {
+			return slotGroupTable(groups)
+		},
+		Text: func() error {
+			return renderSlotGroups(groups)
+		},
+		Empty: "No matching slot combinations.",
+	}
+}
+
+func slotGroupTable(groups []SlotGroup) tableData {
+	table := tableData{Headers: []string{"DATE", "CLUB", "KIND", "TIME", "END", "DURATION", "COURTS", "PRICE", "CURRENCY"}}
+	for _, group := range groups {
+		table.Rows = append(table.Rows, []string{
+			group.Date,
+			group.ClubName,
+			group.Kind,
+			group.Time,
+			group.End,
+			fmt.Sprintf("%d", group.Duration),
+			strings.Join(group.Courts, " + "),
+			plainAmount(group.PriceAmount),
+			group.Currency,
+		})
+	}
+	return table
+}
+
+func renderSlotGroups(groups []SlotGroup) error {
+	if len(groups) == 0 {
+		fmt.Println("No matching slot combinations.")
+		return nil
+	}
+	table := tableData{Headers: []string{"DATE", "CLUB", "TIME", "COURTS", "DURATION", "PRICE"}}
+	for _, group := range groups {
+		table.Rows = append(table.Rows, []string{
+			group.Date,
+			group.ClubName,
+			fmt.Sprintf("%s-%s", group.Time, group.End),
+			strings.Join(group.Courts, " + "),
+			fmt.Sprintf("%dx%dm", len(group.Slots), group.Slots[0].Duration),
+			formatMoney(api.Money{Amount: group.PriceAmount, Currency: group.Currency}),
+		})
+	}
+	return writeTable(os.Stdout, table)
+}
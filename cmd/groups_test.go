@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"padel-cli/api"
+)
+
+func TestGroupFetchFilterKeepsOvernightWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  string
+		opts    groupOptions
+		wantEnd int
+	}{
+		{name: "evening", window: "18:00-21:00", opts: groupOptions{Consecutive: 2, Courts: 1}, wantEnd: 0},
+		{name: "overnight consecutive", window: "22:00-01:00", opts: groupOptions{Consecutive: 2, Courts: 1}, wantEnd: 1500 + 90},
+		{name: "overnight parallel", window: "22:00-01:00", opts: groupOptions{Consecutive: 1, Courts: 2}, wantEnd: 1500},
+		{name: "late run crosses midnight", window: "22:00-23:00", opts: groupOptions{Consecutive: 2, Courts: 1}, wantEnd: 1380 + 90},
+	}
+	for _, tt := range tests {
+		filter := slotFilter{TimeRange: tt.window, TimeMode: timeModeFull, Duration: 90}
+		if err := filter.validate(); err != nil {
+			t.Fatal(err)
+		}
+		fetch := tt.opts.fetchFilter(filter)
+		if got := fetch.lastWindowEnd(); got != tt.wantEnd {
+			t.Errorf("%s: fetch window ends at %d, want %d", tt.name, got, tt.wantEnd)
+		}
+		if tt.wantEnd > 0 && fetch.TimeMode != timeModeStart {
+			t.Errorf("%s: fetch time mode = %q, want %q", tt.name, fetch.TimeMode, timeModeStart)
+		}
+	}
+}
+
+func TestOvernightGroups(t *testing.T) {
+	// Saturday 2026-10-24 in Amsterdam is UTC+2, so Sunday 00:30 local is
+	// 22:30 UTC on Saturday.
+	availability := []api.AvailabilityResource{
+		{ResourceID: "c1", StartDate: "2026-10-24", Slots: []api.Slot{
+			{StartTime: "21:00:00", Duration: 90},
+			{StartTime: "22:30:00", Duration: 90},
+		}},
+		{ResourceID: "c2", StartDate: "2026-10-24", Slots: []api.Slot{
+			{StartTime: "22:30:00", Duration: 90},
+		}},
+	}
+	resources := map[string]api.Resource{
+		"c1": {ResourceID: "c1", Name: "Court 1"},
+		"c2": {ResourceID: "c2", Name: "Court 2"},
+	}
+
+	tests := []struct {
+		name string
+		opts groupOptions
+		want []string
+	}{
+		{name: "consecutive", opts: groupOptions{Consecutive: 2, Courts: 1}, want: []string{"23:00-02:00 Court 1"}},
+		{name: "parallel", opts: groupOptions{Consecutive: 1, Courts: 2}, want: []string{"24:30-02:00 Court 1,Court 2"}},
+	}
+	for _, tt := range tests {
+		filter := slotFilter{ShowAll: true, TimeRange: "22:00-01:00"}
+		if err := filter.validate(); err != nil {
+			t.Fatal(err)
+		}
+		slots := filterAvailabilityWithResources(availability, resources, "2026-10-24", "Europe/Amsterdam", tt.opts.fetchFilter(filter))
+		got := []string{}
+		for _, group := range tt.opts.groups(slots, filter) {
+			courts := ""
+			for i, court := range group.Courts {
+				if i > 0 {
+					courts += ","
+				}
+				courts += court
+			}
+			got = append(got, group.Time+"-"+group.End+" "+courts)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: groups = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -16,11 +16,16 @@ import (
 	"padel-cli/storage"
 )
 
+// resolveLocation accepts a saved location name, "lat,lon" or a place name,
+// in that order.
 func resolveLocation(ctx context.Context, input string) (float64, float64, error) {
+	if saved, ok := savedLocation(input); ok {
+		input = saved
+	}
 	if lat, lon, ok := parseCoordinate(input); ok {
 		return lat, lon, nil
 	}
-	return client.Geocode(ctx, input)
+	return geocode(ctx, input)
 }
 
 func parseCoordinate(input string) (float64, float64, bool) {
@@ -40,22 +45,7 @@ func parseCoordinate(input string) (float64, float64, bool) {
 }
 
 func parseDateInput(input string) (time.Time, error) {
-	if input == "" {
-		return time.Time{}, fmt.Errorf("date is required")
-	}
-	now := time.Now()
-	switch strings.ToLower(input) {
-	case "today":
-		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
-	case "tomorrow":
-		t := now.AddDate(0, 0, 1)
-		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
-	}
-	parsed, err := time.Parse("2006-01-02", input)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", input)
-	}
-	return parsed, nil
+	return parseDateInputInLocation(input, time.Local)
 }
 
 func nextWeekendDates(now time.Time) []time.Time {
@@ -69,25 +59,6 @@ func nextWeekendDates(now time.Time) []time.Time {
 	return []time.Time{saturday, sunday}
 }
 
-func parseTimeRange(input string) (int, int, error) {
-	parts := strings.Split(input, "-")
-	if len(parts) != 2 {
-		return 0, 0, fmt.Errorf("invalid time range %q (expected HH:MM-HH:MM)", input)
-	}
-	start, err := parseClock(strings.TrimSpace(parts[0]))
-	if err != nil {
-		return 0, 0, err
-	}
-	end, err := parseClock(strings.TrimSpace(parts[1]))
-	if err != nil {
-		return 0, 0, err
-	}
-	if end <= start {
-		return 0, 0, fmt.Errorf("time range end must be after start")
-	}
-	return start, end, nil
-}
-
 func parseClock(input string) (int, error) {
 	parsed, err := time.Parse("15:04", input)
 	if err != nil {
@@ -96,6 +67,8 @@ func parseClock(input string) (int, error) {
 	return parsed.Hour()*60 + parsed.Minute(), nil
 }
 
+// slotMinutes parses a slot time. Labels past 24:00, used for the next
+// morning of a window that crosses midnight, are accepted too.
 func slotMinutes(input string) (int, error) {
 	if strings.Count(input, ":") == 2 {
 		parsed, err := time.Parse("15:04:05", input)
@@ -104,6 +77,18 @@ func slotMinutes(input string) (int, error) {
 		}
 		return parsed.Hour()*60 + parsed.Minute(), nil
 	}
+	hours, minutes, ok := strings.Cut(input, ":")
+	if ok && len(hours) == 2 && hours >= "24" {
+		parsed, err := time.Parse("15:04", "00:"+minutes)
+		if err != nil {
+			return 0, err
+		}
+		hour, err := strconv.Atoi(hours)
+		if err != nil || hour >= 48 {
+			return 0, fmt.Errorf("invalid time %q", input)
+		}
+		return hour*60 + parsed.Minute(), nil
+	}
 	parsed, err := time.Parse("15:04", input)
 	if err != nil {
 		return 0, err
@@ -165,22 +150,62 @@ func lookupVenue(alias string) (storage.Venue, error) {
 	return venue, nil
 }
 
+// lookupVenues resolves aliases and "@group" references, in the order given.
+// A venue listed twice is only returned once, at its first position.
 func lookupVenues(aliases []string) ([]storage.Venue, error) {
 	venues, err := storage.LoadVenues()
 	if err != nil {
 		return nil, err
 	}
-	resolved := make([]storage.Venue, 0, len(aliases))
-	for _, alias := range aliases {
+	expanded, err := expandVenueGroups(aliases)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make([]storage.Venue, 0, len(expanded))
+	seen := map[string]bool{}
+	for _, alias := range expanded {
 		venue, ok := storage.FindVenueByAlias(venues, alias)
 		if !ok {
 			return nil, fmt.Errorf("venue alias %q not found", alias)
 		}
+		if seen[venue.ID] {
+			continue
+		}
+		seen[venue.ID] = true
 		resolved = append(resolved, venue)
 	}
 	return resolved, nil
 }
 
+// expandVenueGroups replaces "@name" entries with the aliases of that group.
+func expandVenueGroups(aliases []string) ([]string, error) {
+	var groups map[string][]string
+	expanded := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		name, isGroup := strings.CutPrefix(alias, "@")
+		if !isGroup {
+			expanded = append(expanded, alias)
+			continue
+		}
+		if groups == nil {
+			loaded, err := storage.LoadVenueGroups()
+			if err != nil {
+				return nil, err
+			}
+			groups = loaded
+		}
+		members, ok := storage.FindVenueGroup(groups, name)
+		if !ok {
+			return nil, fmt.Errorf("venue group %q not found", name)
+		}
+		if len(members) == 0 {
+			return nil, fmt.Errorf("venue group %q has no venues", name)
+		}
+		expanded = append(expanded, members...)
+	}
+	return expanded, nil
+}
+
 func normalizeVenueTimezone(tz string) string {
 	if strings.TrimSpace(tz) == "" {
 		return storage.DefaultVenueTimezone
@@ -230,6 +255,20 @@ func apiUTCDateTimeToLocal(dateStr, timeStr, tz string) (string, string, string,
 	return local.Format("2006-01-02"), local.Format("15:04"), startUTC, true
 }
 
+// bookingEnded reports whether booking was over by now. A booking without
+// a known start is not counted as played.
+func bookingEnded(booking storage.Booking, now time.Time) bool {
+	start, ok := parseAPIDateTime(booking.StartUTC)
+	if !ok {
+		return false
+	}
+	duration := booking.Duration
+	if duration <= 0 {
+		duration = storage.DefaultBookingDuration
+	}
+	return !start.Add(time.Duration(duration) * time.Minute).After(now)
+}
+
 func localToUTC(dateStr, timeStr, tz string) (string, error) {
 	loc := venueLocation(tz)
 	parsed, err := time.ParseInLocation("2006-01-02 15:04", fmt.Sprintf("%s %s", dateStr, timeStr), loc)
@@ -240,38 +279,18 @@ func localToUTC(dateStr, timeStr, tz string) (string, error) {
 }
 
 func parseDateInputInLocation(input string, loc *time.Location) (time.Time, error) {
-	if input == "" {
-		return time.Time{}, fmt.Errorf("date is required")
-	}
-	now := time.Now().In(loc)
-	switch strings.ToLower(input) {
-	case "today":
-		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc), nil
-	case "tomorrow":
-		t := now.AddDate(0, 0, 1)
-		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), nil
-	}
-	parsed, err := time.ParseInLocation("2006-01-02", input, loc)
+	days, err := parseDateRangeInLocation(input, loc)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", input)
+		return time.Time{}, err
 	}
-	return parsed, nil
+	if len(days) != 1 {
+		return time.Time{}, fmt.Errorf("date %q matches %d days, expected a single day", input, len(days))
+	}
+	return days[0], nil
 }
 
-func parsePriceAmount(input string) float64 {
-	if input == "" {
-		return 0
-	}
-	fields := strings.Fields(input)
-	if len(fields) == 0 {
-		return 0
-	}
-	value := strings.ReplaceAll(fields[0], ",", ".")
-	amount, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return 0
-	}
-	return amount
+func parseDateRangeInLocation(input string, loc *time.Location) ([]time.Time, error) {
+	return parseDateExpr(input, time.Now().In(loc))
 }
 
 func parseAPIDateTime(input string) (time.Time, bool) {
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"padel-cli/storage"
+)
+
+const (
+	icsProdID       = "-//padel-cli//bookings//EN"
+	icsUIDDomain    = "padel-cli"
+	icsLineLimit    = 75
+	icsTimeLayout   = "20060102T150405Z"
+	defaultDuration = 90
+)
+
+// writeICS writes bookings as an RFC 5545 calendar. UIDs are derived from
+// the booking ID so that re-importing or subscribing updates events in place
+// instead of duplicating them. Bookings without a known start are skipped.
+func writeICS(w io.Writer, bookings []storage.Booking, venueByID, venueByAlias map[string]storage.Venue) error {
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		out.WriteString(foldICSLine(name+":"+value) + "\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", icsProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "Padel")
+
+	for _, booking := range bookings {
+		start, ok := parseAPIDateTime(booking.StartUTC)
+		if !ok {
+			continue
+		}
+		duration := booking.Duration
+		if duration <= 0 {
+			duration = defaultDuration
+		}
+		stamp, ok := parseAPIDateTime(booking.BookedAt)
+		if !ok {
+			stamp = start
+		}
+
+		summary := "Padel at " + booking.VenueName
+		if booking.Court != "" {
+			summary += " (" + booking.Court + ")"
+		}
+		location := booking.VenueName
+		if venue, ok := bookingVenue(booking, venueByID, venueByAlias); ok && venue.Address != "" {
+			location += ", " + venue.Address
+		}
+		description := []string{}
+		if booking.Court != "" {
+			description = append(description, "Court: "+booking.Court)
+		}
+		if booking.Price > 0 {
+			description = append(description, "Price: "+formatMoney(bookingMoney(booking)))
+		}
+		link := bookingLink(booking)
+		if link != "" {
+			description = append(description, link)
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", escapeICSText(booking.ID)+"@"+icsUIDDomain)
+		line("DTSTAMP", stamp.UTC().Format(icsTimeLayout))
+		line("DTSTART", start.UTC().Format(icsTimeLayout))
+		line("DTEND", start.Add(time.Duration(duration)*time.Minute).UTC().Format(icsTimeLayout))
+		line("SUMMARY", escapeICSText(summary))
+		line("LOCATION", escapeICSText(location))
+		if len(description) > 0 {
+			line("DESCRIPTION", escapeICSText(strings.Join(description, "\n")))
+		}
+		if link != "" {
+			line("URL", link)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+func bookingVenue(booking storage.Booking, venueByID, venueByAlias map[string]storage.Venue) (storage.Venue, bool) {
+	if venue, ok := venueByID[booking.VenueID]; ok && booking.VenueID != "" {
+		return venue, true
+	}
+	venue, ok := venueByAlias[strings.ToLower(booking.VenueAlias)]
+	return venue, ok && booking.VenueAlias != ""
+}
+
+// escapeICSText escapes a TEXT value (RFC 5545 section 3.3.11).
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// foldICSLine splits a content line into 75 octet chunks, continuing each
+// with a leading space, without breaking UTF-8 sequences.
+func foldICSLine(value string) string {
+	if len(value) <= icsLineLimit {
+		return value
+	}
+	var folded strings.Builder
+	limit := icsLineLimit
+	for len(value) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		folded.WriteString(value[:cut])
+		folded.WriteString("\r\n ")
+		value = value[cut:]
+		// Continuation lines lose one octet to the leading space.
+		limit = icsLineLimit - 1
+	}
+	folded.WriteString(value)
+	return folded.String()
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"padel-cli/storage"
+)
+
+func TestEscapeICSText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Padel at Blijdorp", want: "Padel at Blijdorp"},
+		{input: "Court 1, indoor; lights", want: `Court 1\, indoor\; lights`},
+		{input: `C:\courts`, want: `C:\\courts`},
+		{input: "Court: 1\nPrice: €40", want: `Court: 1\nPrice: €40`},
+		{input: "line\r\nbreak", want: `line\nbreak`},
+		{input: `\,`, want: `\\\,`},
+	}
+	for _, tt := range tests {
+		if got := escapeICSText(tt.input); got != tt.want {
+			t.Errorf("escapeICSText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "short", input: "SUMMARY:Padel"},
+		{name: "exact limit", input: strings.Repeat("a", icsLineLimit)},
+		{name: "one over", input: strings.Repeat("a", icsLineLimit+1)},
+		{name: "long ascii", input: "DESCRIPTION:" + strings.Repeat("0123456789", 30)},
+		{name: "multibyte", input: "LOCATION:" + strings.Repeat("Café Ñandú ", 20)},
+		{name: "emoji", input: strings.Repeat("🎾", 40)},
+	}
+	for _, tt := range tests {
+		got := foldICSLine(tt.input)
+		if len(tt.input) <= icsLineLimit && got != tt.input {
+			t.Errorf("%s: folded a line that fits: %q", tt.name, got)
+		}
+		lines := strings.Split(got, "\r\n")
+		for i, line := range lines {
+			if len(line) > icsLineLimit {
+				t.Errorf("%s: line %d is %d octets", tt.name, i, len(line))
+			}
+			if !utf8.ValidString(line) {
+				t.Errorf("%s: line %d splits a UTF-8 sequence: %q", tt.name, i, line)
+			}
+			if i > 0 && !strings.HasPrefix(line, " ") {
+				t.Errorf("%s: continuation line %d does not start with a space", tt.name, i)
+			}
+		}
+		if unfolded := strings.ReplaceAll(got, "\r\n ", ""); unfolded != tt.input {
+			t.Errorf("%s: unfolding gives %q, want %q", tt.name, unfolded, tt.input)
+		}
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	bookings := []storage.Booking{
+		{ID: "b1", VenueName: "Blijdorp", Court: "Court 1", StartUTC: "2026-10-24T08:30:00Z", Duration: 60, BookedAt: "2026-10-01T12:00:00Z"},
+		{ID: "no-start", VenueName: "Blijdorp", Date: "2026-10-25", Time: "10:00"},
+	}
+	var out bytes.Buffer
+	if err := writeICS(&out, bookings, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	ics := out.String()
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("calendar is not wrapped in VCALENDAR with CRLF line ends:\n%s", ics)
+	}
+	for _, want := range []string{
+		"UID:b1@padel-cli\r\n",
+		"DTSTAMP:20261001T120000Z\r\n",
+		"DTSTART:20261024T083000Z\r\n",
+		"DTEND:20261024T093000Z\r\n",
+		`SUMMARY:Padel at Blijdorp (Court 1)` + "\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("calendar lacks %q:\n%s", want, ics)
+		}
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 1 || strings.Contains(ics, "no-start") {
+		t.Errorf("bookings without a start should be skipped:\n%s", ics)
+	}
+}
@@ -0,0 +1,492 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// importRow is one record of an import file and what happens to it.
+type importRow struct {
+	Row     int             `json:"row"`
+	Action  string          `json:"action"`
+	Reason  string          `json:"reason,omitempty"`
+	Booking storage.Booking `json:"booking"`
+}
+
+const (
+	importAdd   = "add"
+	importSkip  = "skip"
+	importError = "error"
+)
+
+// importOptions controls how records are turned into bookings.
+type importOptions struct {
+	Mapping    map[string]string
+	Venue      string
+	DateLayout string
+	Dedupe     string
+}
+
+func bookingsImportCmd() *cobra.Command {
+	var format string
+	var mappings []string
+	var dedupe string
+	var dryRun bool
+	var venueAlias string
+	var dateLayout string
+	var delimiter string
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import bookings from a csv or json file",
+		Long: `Import bookings from a csv or json file.
+
+Columns named like the export (id, venue_alias, venue_name, date, time, court,
+price, currency, duration, ...) are picked up automatically; map any other
+column with --map "Column name=field". A "venue" field is matched against saved
+venue aliases first and used as the venue name otherwise.
+
+Rows already in the history are skipped: --dedupe slot (default) compares
+venue, date and time, --dedupe id compares booking IDs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format == "" {
+				format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+			}
+			if format != "csv" && format != "json" {
+				return fmt.Errorf("unsupported import format %q (expected csv or json; use --format)", format)
+			}
+			dedupe = strings.ToLower(strings.TrimSpace(dedupe))
+			if dedupe != "slot" && dedupe != "id" {
+				return fmt.Errorf("--dedupe must be slot or id")
+			}
+			if utf8.RuneCountInString(delimiter) != 1 {
+				return fmt.Errorf("--delimiter must be a single character")
+			}
+			mapping, err := parseColumnMapping(mappings)
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			var records []map[string]string
+			if format == "csv" {
+				delim, _ := utf8.DecodeRuneInString(delimiter)
+				records, err = readCSVRecords(file, delim)
+			} else {
+				records, err = readJSONRecords(file)
+			}
+			if err != nil {
+				return fmt.Errorf("read %s: %w", path, err)
+			}
+
+			db, err := storage.OpenBookingsDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			existing, err := storage.ListBookings(db, storage.BookingFilter{})
+			if err != nil {
+				return err
+			}
+
+			opts := importOptions{Mapping: mapping, Venue: venueAlias, DateLayout: dateLayout, Dedupe: dedupe}
+			rows := planImport(records, existing, opts)
+
+			added := []storage.Booking{}
+			skipped := 0
+			invalid := 0
+			for _, row := range rows {
+				switch row.Action {
+				case importAdd:
+					added = append(added, row.Booking)
+				case importSkip:
+					skipped++
+				case importError:
+					invalid++
+				}
+			}
+
+			if dryRun {
+				return render(renderSpec{
+					Data:  rows,
+					Empty: "Nothing to import.",
+					Table: func() tableData {
+						return importTable(rows)
+					},
+					Text: func() error {
+						if err := writeTable(os.Stdout, importTable(rows)); err != nil {
+							return err
+						}
+						fmt.Printf("\nDry run: would add %d, skip %d duplicates, %d invalid.\n", len(added), skipped, invalid)
+						return nil
+					},
+				})
+			}
+
+			if invalid > 0 {
+				for _, row := range rows {
+					if row.Action == importError {
+						fmt.Fprintf(os.Stderr, "row %d: %s\n", row.Row, row.Reason)
+					}
+				}
+				return fmt.Errorf("%d invalid rows, nothing imported (fix them or map columns with --map; preview with --dry-run)", invalid)
+			}
+			if err := storage.AddBookings(db, added); err != nil {
+				return err
+			}
+
+			summary := map[string]int{"imported": len(added), "skipped": skipped}
+			return render(renderSpec{
+				Data: summary,
+				Table: func() tableData {
+					return tableData{
+						Headers: []string{"IMPORTED", "SKIPPED"},
+						Rows:    [][]string{{fmt.Sprintf("%d", len(added)), fmt.Sprintf("%d", skipped)}},
+					}
+				},
+				Text: func() error {
+					fmt.Printf("Imported %d bookings, skipped %d duplicates.\n", len(added), skipped)
+					return nil
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "File format (csv|json; default from the file extension)")
+	cmd.Flags().StringSliceVar(&mappings, "map", nil, "Map a column to a booking field, e.g. --map \"Datum=date\" (repeatable)")
+	cmd.Flags().StringVar(&dedupe, "dedupe", "slot", "Skip rows matching an existing booking by slot (venue+date+time) or id")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview what would be imported without writing")
+	cmd.Flags().StringVar(&venueAlias, "venue", "", "Saved venue alias for rows without a venue")
+	cmd.Flags().StringVar(&dateLayout, "date-layout", "2006-01-02", "Go layout of the date column, e.g. 02/01/2006")
+	cmd.Flags().StringVar(&delimiter, "delimiter", ",", "csv field delimiter")
+	return cmd
+}
+
+// importFields are the booking fields a column can be mapped to.
+func importFields() map[string]bool {
+	fields := map[string]bool{"venue": true}
+	for _, column := range bookingColumns {
+		fields[column] = true
+	}
+	return fields
+}
+
+func parseColumnMapping(values []string) (map[string]string, error) {
+	fields := importFields()
+	mapping := map[string]string{}
+	for _, value := range values {
+		column, field, ok := strings.Cut(value, "=")
+		column = strings.ToLower(strings.TrimSpace(column))
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid --map %q (expected column=field)", value)
+		}
+		if !fields[field] {
+			return nil, fmt.Errorf("unknown booking field %q in --map %q", field, value)
+		}
+		mapping[column] = field
+	}
+	return mapping, nil
+}
+
+func readCSVRecords(r io.Reader, delimiter rune) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	records := []map[string]string{}
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := map[string]string{}
+		for i, value := range values {
+			if i < len(header) {
+				record[header[i]] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func readJSONRecords(r io.Reader) ([]map[string]string, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var items []map[string]any
+	if err := decoder.Decode(&items); err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		record := map[string]string{}
+		for key, value := range item {
+			if value == nil {
+				continue
+			}
+			record[key] = fmt.Sprint(value)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// planImport converts records to bookings and decides for each whether it is
+// added, skipped as a duplicate of an existing or earlier row, or invalid.
+func planImport(records []map[string]string, existing []storage.Booking, opts importOptions) []importRow {
+	venueByID, venueByAlias := buildVenueLookups()
+	fields := importFields()
+	known := append([]storage.Booking{}, existing...)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	rows := make([]importRow, 0, len(records))
+	for i, record := range records {
+		row := importRow{Row: i + 1}
+
+		values := map[string]string{}
+		for column, value := range record {
+			field, ok := opts.Mapping[strings.ToLower(strings.TrimSpace(column))]
+			if !ok {
+				field = strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(column)))
+				if !fields[field] {
+					continue
+				}
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				values[field] = value
+			}
+		}
+
+		booking, err := importBooking(values, opts, venueByID, venueByAlias)
+		if err != nil {
+			row.Action = importError
+			row.Reason = err.Error()
+			rows = append(rows, row)
+			continue
+		}
+		if booking.BookedAt == "" {
+			booking.BookedAt = now
+		}
+		row.Booking = booking
+
+		if duplicate, ok := findDuplicate(booking, known, opts.Dedupe); ok {
+			row.Action = importSkip
+			row.Reason = "duplicate of " + duplicate.ID
+		} else {
+			row.Action = importAdd
+			known = append(known, booking)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func importBooking(values map[string]string, opts importOptions, venueByID, venueByAlias map[string]storage.Venue) (storage.Booking, error) {
+	booking := storage.Booking{
+		ID:            values["id"],
+		VenueAlias:    values["venue_alias"],
+		VenueName:     values["venue_name"],
+		VenueID:       values["venue_id"],
+		Court:         values["court"],
+		StartUTC:      values["start_utc"],
+		VenueTimezone: values["venue_timezone"],
+		BookedAt:      values["booked_at"],
+		Source:        values["source"],
+	}
+
+	if venue := values["venue"]; venue != "" {
+		if _, ok := venueByAlias[strings.ToLower(venue)]; ok && booking.VenueAlias == "" {
+			booking.VenueAlias = venue
+		} else if booking.VenueName == "" {
+			booking.VenueName = venue
+		}
+	}
+	if booking.VenueAlias == "" && booking.VenueName == "" && booking.VenueID == "" {
+		booking.VenueAlias = opts.Venue
+	}
+	saved, ok := venueByID[booking.VenueID]
+	if !ok || booking.VenueID == "" {
+		saved, ok = venueByAlias[strings.ToLower(booking.VenueAlias)]
+		ok = ok && booking.VenueAlias != ""
+	}
+	if ok {
+		booking.VenueAlias = saved.Alias
+		booking.VenueID = saved.ID
+		if booking.VenueName == "" {
+			booking.VenueName = saved.Name
+		}
+		if booking.VenueTimezone == "" {
+			booking.VenueTimezone = saved.TimeZone
+		}
+	} else if booking.VenueAlias != "" && booking.VenueName == "" && booking.VenueID == "" {
+		return booking, fmt.Errorf("venue %q not found", booking.VenueAlias)
+	}
+	if booking.VenueName == "" {
+		booking.VenueName = booking.VenueAlias
+	}
+	if booking.VenueName == "" {
+		return booking, fmt.Errorf("missing venue")
+	}
+	booking.VenueTimezone = normalizeVenueTimezone(booking.VenueTimezone)
+
+	if date := values["date"]; date != "" {
+		parsed, err := time.Parse(opts.DateLayout, date)
+		if err != nil {
+			return booking, fmt.Errorf("invalid date %q (expected layout %s)", date, opts.DateLayout)
+		}
+		booking.Date = parsed.Format("2006-01-02")
+	}
+	if clock := values["time"]; clock != "" {
+		minutes, err := parseClock(clockPrefix(clock))
+		if err != nil {
+			return booking, err
+		}
+		booking.Time = fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	}
+	switch {
+	case booking.StartUTC != "":
+		// Store start_utc in the one layout the booking queries compare
+		// against, and make sure it names the same moment as date and time.
+		localDate, localTime, startUTC, ok := apiUTCToLocal(booking.StartUTC, booking.VenueTimezone)
+		if !ok {
+			return booking, fmt.Errorf("invalid start_utc %q", booking.StartUTC)
+		}
+		if (booking.Date != "" && booking.Date != localDate) || (booking.Time != "" && booking.Time != localTime) {
+			return booking, fmt.Errorf("start_utc %q is %s %s in %s, not %s %s", booking.StartUTC, localDate, localTime, booking.VenueTimezone, booking.Date, booking.Time)
+		}
+		booking.Date, booking.Time, booking.StartUTC = localDate, localTime, startUTC
+	case booking.Date != "" && booking.Time != "":
+		startUTC, err := localToUTC(booking.Date, booking.Time, booking.VenueTimezone)
+		if err != nil {
+			return booking, err
+		}
+		booking.StartUTC = startUTC
+	default:
+		return booking, fmt.Errorf("missing date or time")
+	}
+
+	booking.Duration = defaultDuration
+	if value := values["duration"]; value != "" {
+		duration, err := strconv.Atoi(value)
+		if err != nil || duration <= 0 {
+			return booking, fmt.Errorf("invalid duration %q", value)
+		}
+		booking.Duration = duration
+	}
+
+	booking.Currency = strings.ToUpper(values["currency"])
+	if value := values["price"]; value != "" {
+		format := moneyFormat(booking.VenueTimezone)
+		if kronaCurrencies[booking.Currency] {
+			// A currency column settles which krona "kr" is.
+			format.Krona = booking.Currency
+		}
+		price, err := api.ParseMoneyFormat(value, format)
+		if err != nil {
+			return booking, err
+		}
+		if price.Currency == "" && booking.Currency == "" && strings.IndexFunc(value, unicode.IsLetter) >= 0 {
+			return booking, fmt.Errorf("currency of price %q is ambiguous; add a currency column or --map one", value)
+		}
+		booking.Price = price.Amount
+		if price.Currency != "" {
+			booking.Currency = price.Currency
+		}
+	}
+	if booking.Currency == "" {
+		booking.Currency = defaultCurrency
+	}
+
+	if booking.ID == "" {
+		booking.ID = newBookingID()
+	}
+	if booking.Source == "" {
+		booking.Source = "import"
+	}
+	return booking, nil
+}
+
+// findDuplicate looks for a booking with the same ID, or with dedupe "slot"
+// the same venue, date and time.
+func findDuplicate(booking storage.Booking, known []storage.Booking, dedupe string) (storage.Booking, bool) {
+	for _, other := range known {
+		if other.ID == booking.ID {
+			return other, true
+		}
+		if dedupe == "slot" && other.Date == booking.Date && other.Time == booking.Time && sameVenue(other, booking) {
+			return other, true
+		}
+	}
+	return storage.Booking{}, false
+}
+
+func sameVenue(left, right storage.Booking) bool {
+	if left.VenueID != "" && right.VenueID != "" {
+		return left.VenueID == right.VenueID
+	}
+	if left.VenueAlias != "" && strings.EqualFold(left.VenueAlias, right.VenueAlias) {
+		return true
+	}
+	return left.VenueName != "" && strings.EqualFold(left.VenueName, right.VenueName)
+}
+
+func importTable(rows []importRow) tableData {
+	table := tableData{Headers: []string{"ROW", "ACTION", "DATE", "TIME", "VENUE", "COURT", "PRICE", "NOTE"}}
+	for _, row := range rows {
+		price := ""
+		if row.Action != importError {
+			price = formatMoney(bookingMoney(row.Booking))
+		}
+		table.Rows = append(table.Rows, []string{
+			fmt.Sprintf("%d", row.Row),
+			row.Action,
+			row.Booking.Date,
+			row.Booking.Time,
+			row.Booking.VenueName,
+			row.Booking.Court,
+			price,
+			row.Reason,
+		})
+	}
+	return table
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// LevelReport is the current user's level history with the latest levels of
+// the people they play with.
+type LevelReport struct {
+	UserID   string                  `json:"user_id"`
+	Name     string                  `json:"name"`
+	Current  *storage.LevelSnapshot  `json:"current,omitempty"`
+	History  []storage.LevelSnapshot `json:"history"`
+	Partners []Partner               `json:"partners"`
+}
+
+const (
+	levelSourceProfile = "profile"
+	levelSourceMatch   = "match"
+)
+
+func levelCmd() *cobra.Command {
+	var offline bool
+	var partnersLimit int
+
+	cmd := &cobra.Command{
+		Use:   "level",
+		Short: "Show your level over time and your partners' levels",
+		Long:  "Fetch your Playtomic level, record it in the level history (sync records one too) and show the trend next to the latest levels of the people you play with.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			creds, err := storage.LoadCredentials()
+			if err != nil {
+				return err
+			}
+			if creds == nil || creds.UserID == "" {
+				return fmt.Errorf("not logged in. Run 'padel auth login' first")
+			}
+
+			db, err := storage.OpenBookingsDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if !offline {
+				if creds.AccessToken == "" || creds.AccessTokenExpired(time.Now()) {
+					fmt.Fprintln(os.Stderr, "warning: token expired, showing stored levels only. Run 'padel auth login' to refresh")
+				} else {
+					client.AccessToken = creds.AccessToken
+					if _, err := recordLevel(context.Background(), db, creds.UserID); err != nil {
+						fmt.Fprintf(os.Stderr, "warning: could not fetch level: %v\n", err)
+					}
+				}
+			}
+
+			history, err := storage.ListLevelHistory(db, creds.UserID)
+			if err != nil {
+				return err
+			}
+			players, err := storage.ListMatchPlayers(db)
+			if err != nil {
+				return err
+			}
+			bookings, err := loadBookings(storage.BookingFilter{})
+			if err != nil {
+				return err
+			}
+
+			report := LevelReport{UserID: creds.UserID, History: history}
+			if len(history) > 0 {
+				current := history[len(history)-1]
+				report.Current = &current
+				report.Name = current.Name
+			}
+			report.Partners = partnersByLevel(computePartners(bookings, players, creds.UserID, time.Now()), partnersLimit)
+
+			return render(renderSpec{
+				Data: report,
+				Table: func() tableData {
+					return levelHistoryTable(dailyLevels(history))
+				},
+				Text: func() error {
+					return renderLevelReport(report)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&offline, "offline", false, "Show stored levels without fetching the current one")
+	cmd.Flags().IntVar(&partnersLimit, "partners", 10, "Show the levels of this many frequent partners (0 for none)")
+	return cmd
+}
+
+// recordLevel fetches the user's level and adds it to the level history.
+// When the profile has no padel level, the level from the user's most
+// recent synced match is used instead.
+func recordLevel(ctx context.Context, db *sql.DB, userID string) (bool, error) {
+	snapshot := storage.LevelSnapshot{
+		UserID:     userID,
+		Source:     levelSourceProfile,
+		RecordedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if profile, err := client.GetUser(ctx, userID); err == nil {
+		snapshot.Name = profile.FullName
+	}
+
+	level, ok, err := client.GetPadelLevel(ctx, userID)
+	if err == nil && ok {
+		snapshot.LevelValue = level.LevelValue
+		snapshot.LevelConfidence = level.LevelConfidence
+	} else {
+		player, found, rosterErr := storage.LatestPlayerLevel(db, userID)
+		if rosterErr != nil {
+			return false, rosterErr
+		}
+		if !found {
+			if err != nil {
+				return false, err
+			}
+			return false, fmt.Errorf("no padel level on the profile yet")
+		}
+		snapshot.LevelValue = player.LevelValue
+		snapshot.LevelConfidence = player.LevelConfidence
+		snapshot.Source = levelSourceMatch
+		if snapshot.Name == "" {
+			snapshot.Name = player.Name
+		}
+	}
+	return storage.AddLevelSnapshot(db, snapshot)
+}
+
+// dailyLevels keeps the last snapshot of each day.
+func dailyLevels(history []storage.LevelSnapshot) []storage.LevelSnapshot {
+	daily := []storage.LevelSnapshot{}
+	for _, snapshot := range history {
+		day := snapshotDate(snapshot)
+		if len(daily) > 0 && snapshotDate(daily[len(daily)-1]) == day {
+			daily[len(daily)-1] = snapshot
+			continue
+		}
+		daily = append(daily, snapshot)
+	}
+	return daily
+}
+
+func snapshotDate(snapshot storage.LevelSnapshot) string {
+	if parsed, ok := parseAPIDateTime(snapshot.RecordedAt); ok {
+		return parsed.Local().Format("2006-01-02")
+	}
+	if len(snapshot.RecordedAt) >= 10 {
+		return snapshot.RecordedAt[:10]
+	}
+	return snapshot.RecordedAt
+}
+
+// partnersByLevel returns the n most frequent partners ordered by level,
+// highest first, to help balance teams.
+func partnersByLevel(partners []Partner, n int) []Partner {
+	if n <= 0 {
+		return []Partner{}
+	}
+	if len(partners) > n {
+		partners = partners[:n]
+	}
+	sorted := append([]Partner{}, partners...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Level > sorted[j].Level
+	})
+	return sorted
+}
+
+func levelHistoryTable(history []storage.LevelSnapshot) tableData {
+	table := tableData{Headers: []string{"DATE", "LEVEL", "CHANGE", "CONFIDENCE", "SOURCE"}}
+	for i, snapshot := range history {
+		change := ""
+		if i > 0 {
+			if delta := roundCents(snapshot.LevelValue - history[i-1].LevelValue); delta != 0 {
+				change = fmt.Sprintf("%+.2f", delta)
+			}
+		}
+		table.Rows = append(table.Rows, []string{
+			snapshotDate(snapshot),
+			fmt.Sprintf("%.2f", snapshot.LevelValue),
+			change,
+			confidenceLabel(snapshot.LevelConfidence),
+			snapshot.Source,
+		})
+	}
+	return table
+}
+
+// confidenceLabel shows a 0-1 confidence as a percentage.
+func confidenceLabel(confidence float64) string {
+	if confidence <= 0 {
+		return ""
+	}
+	if confidence <= 1 {
+		confidence *= 100
+	}
+	return fmt.Sprintf("%.0f%%", confidence)
+}
+
+func renderLevelReport(report LevelReport) error {
+	if report.Current == nil {
+		fmt.Println("No level recorded yet. Run 'padel level' while logged in, or 'padel bookings sync'.")
+	} else {
+		name := report.Name
+		if name == "" {
+			name = "You"
+		}
+		line := fmt.Sprintf("%s: level %.2f", name, report.Current.LevelValue)
+		if confidence := confidenceLabel(report.Current.LevelConfidence); confidence != "" {
+			line += fmt.Sprintf(" (confidence %s)", confidence)
+		}
+		fmt.Println(line)
+
+		daily := dailyLevels(report.History)
+		if len(daily) > 1 {
+			levels := make([]float64, 0, len(daily))
+			for _, snapshot := range daily {
+				levels = append(levels, snapshot.LevelValue)
+			}
+			change := daily[len(daily)-1].LevelValue - daily[0].LevelValue
+			fmt.Printf("Trend since %s: %s %+.2f\n", snapshotDate(daily[0]), levelSparkline(levels), change)
+		}
+		fmt.Println()
+		if err := writeTable(os.Stdout, levelHistoryTable(daily)); err != nil {
+			return err
+		}
+	}
+
+	if len(report.Partners) == 0 {
+		return nil
+	}
+	fmt.Println("\nPartners")
+	table := tableData{Headers: []string{"NAME", "LEVEL", "VS_YOU", "TREND", "GAMES"}}
+	for _, partner := range report.Partners {
+		versus := ""
+		if report.Current != nil && partner.Level > 0 {
+			versus = fmt.Sprintf("%+.2f", partner.Level-report.Current.LevelValue)
+		}
+		levels := make([]float64, 0, len(partner.Levels))
+		for _, point := range partner.Levels {
+			levels = append(levels, point.Level)
+		}
+		table.Rows = append(table.Rows, []string{
+			partner.Name,
+			levelLabel(partner.Level, partner.LevelChange),
+			versus,
+			levelSparkline(levels),
+			fmt.Sprintf("%d", partner.Games),
+		})
+	}
+	return writeTable(os.Stdout, table)
+}
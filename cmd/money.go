@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+)
+
+const defaultCurrency = storage.DefaultCurrency
+
+type numberLocale struct {
+	Decimal     string
+	Group       string
+	SymbolAfter bool
+	SymbolSpace bool
+}
+
+var localeFormats = map[string]numberLocale{
+	"en": {Decimal: ".", Group: ","},
+	"nl": {Decimal: ",", Group: ".", SymbolSpace: true},
+	"de": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true},
+	"es": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true},
+	"it": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true},
+	"pt": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true},
+	"fr": {Decimal: ",", Group: " ", SymbolAfter: true, SymbolSpace: true},
+	"sv": {Decimal: ",", Group: " ", SymbolAfter: true, SymbolSpace: true},
+	"nb": {Decimal: ",", Group: " ", SymbolAfter: true, SymbolSpace: true},
+	"da": {Decimal: ",", Group: ".", SymbolAfter: true, SymbolSpace: true},
+	"fi": {Decimal: ",", Group: " ", SymbolAfter: true, SymbolSpace: true},
+}
+
+var currencyDisplaySymbols = map[string]string{
+	"EUR": "€",
+	"GBP": "£",
+	"USD": "$",
+	"SEK": "kr",
+	"NOK": "kr",
+	"DKK": "kr",
+}
+
+// kronaByLocale and kronaByTimezone tell which krona a bare "kr" means.
+var kronaByLocale = map[string]string{
+	"sv": "SEK",
+	"nb": "NOK",
+	"da": "DKK",
+}
+
+var kronaByTimezone = map[string]string{
+	"Europe/Stockholm":  "SEK",
+	"Europe/Oslo":       "NOK",
+	"Europe/Copenhagen": "DKK",
+}
+
+var kronaCurrencies = map[string]bool{
+	"SEK": true,
+	"NOK": true,
+	"DKK": true,
+}
+
+// userLocale returns the language part of the configured locale, falling back
+// to the usual POSIX environment variables.
+func userLocale() string {
+	candidates := []string{cfg.Locale, os.Getenv("LC_ALL"), os.Getenv("LC_MONETARY"), os.Getenv("LANG")}
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" || candidate == "C" || candidate == "POSIX" {
+			continue
+		}
+		fields := strings.FieldsFunc(candidate, func(r rune) bool {
+			return r == '_' || r == '-' || r == '.' || r == '@'
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		lang := strings.ToLower(fields[0])
+		if lang == "no" || lang == "nn" {
+			lang = "nb"
+		}
+		return lang
+	}
+	return "en"
+}
+
+func formatMoney(money api.Money) string {
+	currency := money.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	format, ok := localeFormats[userLocale()]
+	if !ok {
+		format = localeFormats["en"]
+	}
+
+	number := formatNumber(money.Amount, format)
+	symbol, ok := currencyDisplaySymbols[currency]
+	if !ok || (symbol == "kr" && !format.SymbolAfter) {
+		// Letter symbols read oddly in front of the amount, so use the ISO code.
+		return fmt.Sprintf("%s %s", currency, number)
+	}
+	space := ""
+	if format.SymbolSpace {
+		space = " "
+	}
+	if format.SymbolAfter {
+		return number + space + symbol
+	}
+	return symbol + space + number
+}
+
+func formatNumber(amount float64, format numberLocale) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	cents := int64(math.Round(amount * 100))
+	whole := fmt.Sprintf("%d", cents/100)
+	fraction := fmt.Sprintf("%02d", cents%100)
+
+	groups := []string{}
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+	return sign + strings.Join(groups, format.Group) + format.Decimal + fraction
+}
+
+func bookingMoney(booking storage.Booking) api.Money {
+	currency := booking.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	return api.Money{Amount: booking.Price, Currency: currency}
+}
+
+// moneyFormat reads prices typed by the user, e.g. in an imported sheet,
+// with the separators of their locale. "kr" is the krona of the venue's
+// timezone, or else of the user's locale.
+func moneyFormat(venueTimezone string) api.MoneyFormat {
+	lang := userLocale()
+	format := api.MoneyFormat{Krona: kronaByTimezone[venueTimezone]}
+	if format.Krona == "" {
+		format.Krona = kronaByLocale[lang]
+	}
+	if numbers, ok := localeFormats[lang]; ok {
+		format.Decimal = numbers.Decimal
+	}
+	return format
+}
+
+// parsePrice parses a Playtomic price string, defaulting to EUR when no
+// currency is named. "kr" is the krona of the venue's timezone, or else of
+// the user's locale; without either it is an error.
+func parsePrice(input, venueTimezone string) (api.Money, error) {
+	format := moneyFormat(venueTimezone)
+	// Playtomic always uses a decimal point.
+	format.Decimal = "."
+	money, err := api.ParseMoneyFormat(input, format)
+	if err != nil {
+		return api.Money{}, err
+	}
+	if money.Currency == "" {
+		if strings.IndexFunc(input, unicode.IsLetter) >= 0 {
+			return api.Money{}, fmt.Errorf("currency of price %q is ambiguous", input)
+		}
+		money.Currency = defaultCurrency
+	}
+	return money, nil
+}
+
+// formatPriceLabel reformats a Playtomic price string for the user's locale,
+// keeping the original text when it cannot be parsed.
+func formatPriceLabel(input, venueTimezone string) string {
+	if strings.TrimSpace(input) == "" {
+		return ""
+	}
+	price, err := parsePrice(input, venueTimezone)
+	if err != nil {
+		return input
+	}
+	return formatMoney(price)
+}
+
+// sumByCurrency totals amounts per currency, ordered by currency code.
+func sumByCurrency(amounts []api.Money) []api.Money {
+	totals := map[string]float64{}
+	for _, amount := range amounts {
+		currency := amount.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		totals[currency] += amount.Amount
+	}
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	sums := make([]api.Money, 0, len(currencies))
+	for _, currency := range currencies {
+		sums = append(sums, api.Money{Amount: totals[currency], Currency: currency})
+	}
+	return sums
+}
+
+func formatMoneyList(amounts []api.Money) string {
+	if len(amounts) == 0 {
+		return formatMoney(api.Money{})
+	}
+	parts := make([]string, 0, len(amounts))
+	for _, amount := range amounts {
+		parts = append(parts, formatMoney(amount))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plainMoneyList renders amounts without locale formatting, for csv and other
+// machine-readable tables.
+func plainMoneyList(amounts []api.Money) string {
+	parts := make([]string, 0, len(amounts))
+	for _, amount := range amounts {
+		parts = append(parts, fmt.Sprintf("%.2f %s", amount.Amount, amount.Currency))
+	}
+	return strings.Join(parts, "; ")
+}
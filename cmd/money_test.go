@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+
+	"padel-cli/api"
+)
+
+func TestParsePrice(t *testing.T) {
+	saved := cfg.Locale
+	t.Cleanup(func() { cfg.Locale = saved })
+
+	tests := []struct {
+		input    string
+		timezone string
+		locale   string
+		want     api.Money
+	}{
+		{input: "300 kr", timezone: "Europe/Stockholm", locale: "en", want: api.Money{Amount: 300, Currency: "SEK"}},
+		{input: "300 kr", timezone: "Europe/Oslo", locale: "sv", want: api.Money{Amount: 300, Currency: "NOK"}},
+		{input: "300 kr", timezone: "Europe/Amsterdam", locale: "da", want: api.Money{Amount: 300, Currency: "DKK"}},
+		{input: "43.68 EUR", timezone: "Europe/Amsterdam", locale: "de", want: api.Money{Amount: 43.68, Currency: "EUR"}},
+		{input: "43.680 EUR", timezone: "Europe/Amsterdam", locale: "de", want: api.Money{Amount: 43.68, Currency: "EUR"}},
+		{input: "40", timezone: "Europe/Amsterdam", locale: "en", want: api.Money{Amount: 40, Currency: "EUR"}},
+	}
+	for _, tt := range tests {
+		cfg.Locale = tt.locale
+		got, err := parsePrice(tt.input, tt.timezone)
+		if err != nil {
+			t.Errorf("parsePrice(%q, %s) with %s error: %v", tt.input, tt.timezone, tt.locale, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePrice(%q, %s) with %s = %+v, want %+v", tt.input, tt.timezone, tt.locale, got, tt.want)
+		}
+	}
+
+	cfg.Locale = "en"
+	for _, input := range []string{"free", "300 kr", "12 E$"} {
+		if got, err := parsePrice(input, "Europe/Amsterdam"); err == nil {
+			t.Errorf("parsePrice(%q) = %+v, want error", input, got)
+		}
+	}
+}
+
+func TestApplySlotPricingLeavesUnparseablePricesUnpriced(t *testing.T) {
+	slots := applySlotPricing([]AvailabilitySlot{
+		{Court: "odd", Time: "10:00", Duration: 60, Price: "on request", VenueTimezone: "Europe/Amsterdam"},
+		{Court: "priced", Time: "10:00", Duration: 60, Price: "40 EUR", VenueTimezone: "Europe/Amsterdam"},
+	}, 4)
+
+	odd := slots[0]
+	if slotPriced(odd) || odd.PriceAmount != 0 || formatPerPlayer(odd) != "" {
+		t.Errorf("unparseable slot is priced: %+v", odd)
+	}
+	if got := formatPriceLabel(odd.Price, odd.VenueTimezone); got != "on request" {
+		t.Errorf("formatPriceLabel = %q, want the original text", got)
+	}
+
+	// An unknown price neither passes as free nor sorts first.
+	kept := filterSlotsByPrice(slots, api.Money{Amount: 30, Currency: "EUR"}, api.Money{})
+	if got := slotCourts(kept); len(got) != 1 || got[0] != "odd" {
+		t.Errorf("filterSlotsByPrice kept %v, want only the unpriced slot", got)
+	}
+	sortSlotsByPrice(slots)
+	if got := slotCourts(slots); got[0] != "priced" {
+		t.Errorf("sortSlotsByPrice order = %v, want the priced slot first", got)
+	}
+	if cheapest, ok := cheapestSlot(slots); !ok || cheapest.Court != "priced" {
+		t.Errorf("cheapestSlot = %s, %v, want priced", cheapest.Court, ok)
+	}
+}
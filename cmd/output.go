@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+type outputFormat string
+
+const (
+	formatTable    outputFormat = "table"
+	formatJSON     outputFormat = "json"
+	formatNDJSON   outputFormat = "ndjson"
+	formatCSV      outputFormat = "csv"
+	formatMarkdown outputFormat = "markdown"
+	formatCompact  outputFormat = "compact"
+	formatTemplate outputFormat = "template"
+	formatWhatsApp outputFormat = "whatsapp"
+	formatTelegram outputFormat = "telegram-markdown"
+	formatSlack    outputFormat = "slack-mrkdwn"
+)
+
+var outputFormats = []outputFormat{
+	formatTable,
+	formatJSON,
+	formatNDJSON,
+	formatCSV,
+	formatMarkdown,
+	formatCompact,
+	formatTemplate,
+	formatWhatsApp,
+	formatTelegram,
+	formatSlack,
+}
+
+var outputAliases = map[string]outputFormat{
+	"md":       formatMarkdown,
+	"chat":     formatWhatsApp,
+	"telegram": formatTelegram,
+	"slack":    formatSlack,
+}
+
+var (
+	outputName     string
+	outputTemplate string
+	selectedOutput = formatTable
+)
+
+// tableData is the flat view of a result used by table, csv and markdown output.
+type tableData struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// renderSpec describes one command result. Data is what json, ndjson and
+// template output see; the optional callbacks override the generic renderers.
+type renderSpec struct {
+	Data    any
+	Records func() []any
+	Table   func() tableData
+	Text    func() error
+	Compact func() error
+	Chat    func(c chatFormatter) string
+	Empty   string
+}
+
+func resolveOutputFormat() error {
+	selected := []string{}
+	if outputJSON {
+		selected = append(selected, "--json")
+	}
+	if outputCompact {
+		selected = append(selected, "--compact")
+	}
+	if outputName != "" {
+		selected = append(selected, "--output")
+	}
+	if len(selected) > 1 {
+		return fmt.Errorf("choose only one of %s", strings.Join(selected, ", "))
+	}
+
+	switch {
+	case outputJSON:
+		selectedOutput = formatJSON
+	case outputCompact:
+		selectedOutput = formatCompact
+	case outputName != "":
+		format, err := parseOutputFormat(outputName)
+		if err != nil {
+			return err
+		}
+		selectedOutput = format
+	case outputTemplate != "":
+		selectedOutput = formatTemplate
+	default:
+		selectedOutput = formatTable
+	}
+
+	if selectedOutput == formatTemplate && outputTemplate == "" {
+		return fmt.Errorf("--output template requires --template")
+	}
+	if selectedOutput != formatTemplate && outputTemplate != "" {
+		return fmt.Errorf("--template can only be used with --output template")
+	}
+	return nil
+}
+
+func parseOutputFormat(input string) (outputFormat, error) {
+	needle := strings.ToLower(strings.TrimSpace(input))
+	if format, ok := outputAliases[needle]; ok {
+		return format, nil
+	}
+	for _, format := range outputFormats {
+		if string(format) == needle {
+			return format, nil
+		}
+	}
+	names := make([]string, 0, len(outputFormats))
+	for _, format := range outputFormats {
+		names = append(names, string(format))
+	}
+	return "", fmt.Errorf("invalid output %q (expected %s)", input, strings.Join(names, "|"))
+}
+
+func render(spec renderSpec) error {
+	switch selectedOutput {
+	case formatJSON:
+		return writeJSON(spec.Data)
+	case formatNDJSON:
+		return writeNDJSON(os.Stdout, spec.records())
+	case formatTemplate:
+		return writeTemplate(os.Stdout, outputTemplate, spec.Data)
+	case formatCSV:
+		return writeCSV(os.Stdout, spec.table())
+	case formatMarkdown:
+		return writeMarkdown(os.Stdout, spec.table())
+	case formatWhatsApp, formatTelegram, formatSlack:
+		if spec.Chat != nil {
+			fmt.Println(spec.Chat(chatFormatter{format: selectedOutput}))
+			return nil
+		}
+		return renderCompact(spec)
+	case formatCompact:
+		return renderCompact(spec)
+	default:
+		if spec.Text != nil {
+			return spec.Text()
+		}
+		table := spec.table()
+		if len(table.Rows) == 0 && spec.Empty != "" {
+			fmt.Println(spec.Empty)
+			return nil
+		}
+		return writeTable(os.Stdout, table)
+	}
+}
+
+func renderCompact(spec renderSpec) error {
+	if spec.Compact != nil {
+		return spec.Compact()
+	}
+	table := spec.table()
+	if len(table.Rows) == 0 && spec.Empty != "" {
+		fmt.Println(spec.Empty)
+		return nil
+	}
+	return writeTable(os.Stdout, tableData{Rows: table.Rows})
+}
+
+func (spec renderSpec) table() tableData {
+	if spec.Table == nil {
+		return tableData{}
+	}
+	return spec.Table()
+}
+
+func (spec renderSpec) records() []any {
+	if spec.Records != nil {
+		return spec.Records()
+	}
+	value := reflect.ValueOf(spec.Data)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		records := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			records = append(records, value.Index(i).Interface())
+		}
+		return records
+	}
+	return []any{spec.Data}
+}
+
+func writeTable(w io.Writer, table tableData) error {
+	writer := tabwriter.NewWriter(w, 2, 2, 2, ' ', 0)
+	if len(table.Headers) > 0 {
+		fmt.Fprintln(writer, strings.Join(table.Headers, "\t"))
+	}
+	for _, row := range table.Rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+func writeCSV(w io.Writer, table tableData) error {
+	writer := csv.NewWriter(w)
+	if len(table.Headers) > 0 {
+		if err := writer.Write(table.Headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range table.Rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeMarkdown(w io.Writer, table tableData) error {
+	if len(table.Headers) == 0 {
+		return nil
+	}
+	separators := make([]string, len(table.Headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	lines := []string{
+		markdownRow(table.Headers),
+		markdownRow(separators),
+	}
+	for _, row := range table.Rows {
+		lines = append(lines, markdownRow(row))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		escaped = append(escaped, cell)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+func writeNDJSON(w io.Writer, records []any) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTemplate(w io.Writer, text string, data any) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	// Round-trip through JSON so templates use the same field names as --json.
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, value); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"join":  joinValues,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"json": func(v any) (string, error) {
+			raw, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return string(raw), nil
+		},
+	}
+}
+
+func joinValues(items any, sep string) string {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(items)
+	}
+	parts := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		parts = append(parts, fmt.Sprint(value.Index(i).Interface()))
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// Partner summarises the games played with one other player.
+type Partner struct {
+	UserID      string       `json:"user_id"`
+	Name        string       `json:"name"`
+	Games       int          `json:"games"`
+	RecentGames int          `json:"recent_games"`
+	LastPlayed  string       `json:"last_played"`
+	Venues      []string     `json:"venues"`
+	SharedSpend []api.Money  `json:"shared_spend"`
+	Level       float64      `json:"level"`
+	LevelChange float64      `json:"level_change"`
+	Levels      []LevelPoint `json:"levels"`
+}
+
+// LevelPoint is a player's level as reported for a match on date.
+type LevelPoint struct {
+	Date       string  `json:"date"`
+	Level      float64 `json:"level"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// usualWindow is how far back games count as recent when ranking who we
+// usually invite.
+const usualWindow = 180 * 24 * time.Hour
+
+func partnersCmd() *cobra.Command {
+	var from string
+	var to string
+	var venueAliases string
+	var limit int
+	var usual bool
+
+	cmd := &cobra.Command{
+		Use:   "partners",
+		Short: "Show who you play with",
+		Long:  "Show who you play with, built from the players fetched by 'padel bookings sync': games together, the last one, venues, the combined court price of those games and how their level moved. --usual lists who you usually invite, ranked by games in the last six months.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := storage.BookingFilter{}
+			if from != "" {
+				date, err := parseDateInput(from)
+				if err != nil {
+					return err
+				}
+				filter.From = date.Format("2006-01-02")
+			}
+			if to != "" {
+				date, err := parseDateInput(to)
+				if err != nil {
+					return err
+				}
+				filter.To = date.Format("2006-01-02")
+			}
+
+			userID, err := currentUserID()
+			if err != nil {
+				return err
+			}
+			filter.Venues, err = venueFilterValues(venueAliases)
+			if err != nil {
+				return err
+			}
+			bookings, err := loadBookings(filter)
+			if err != nil {
+				return err
+			}
+			players, err := loadMatchPlayers()
+			if err != nil {
+				return err
+			}
+
+			partners := computePartners(bookings, players, userID, time.Now())
+			if usual {
+				partners = usualInvitees(partners)
+			}
+			if limit > 0 && len(partners) > limit {
+				partners = partners[:limit]
+			}
+
+			empty := "No partners found. Run 'padel bookings sync' to fetch who played in your matches."
+			if usual {
+				return render(renderSpec{
+					Data:  partners,
+					Empty: empty,
+					Table: func() tableData {
+						table := tableData{Headers: []string{"RANK", "NAME", "RECENT_GAMES", "GAMES", "LAST_PLAYED"}}
+						for i, partner := range partners {
+							table.Rows = append(table.Rows, []string{fmt.Sprintf("%d", i+1), partner.Name, fmt.Sprintf("%d", partner.RecentGames), fmt.Sprintf("%d", partner.Games), partner.LastPlayed})
+						}
+						return table
+					},
+					Chat: func(c chatFormatter) string {
+						names := make([]string, 0, len(partners))
+						for _, partner := range partners {
+							names = append(names, partner.Name)
+						}
+						return "👥 " + c.text(strings.Join(names, ", "))
+					},
+				})
+			}
+			return render(renderSpec{
+				Data:  partners,
+				Empty: empty,
+				Table: func() tableData {
+					return partnersTable(partners)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Only count games on/after this date")
+	cmd.Flags().StringVar(&to, "to", "", "Only count games on/before this date")
+	cmd.Flags().StringVar(&venueAliases, "venue", "", "Only count games at these saved venues (comma-separated, @group)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show at most this many partners")
+	cmd.Flags().BoolVar(&usual, "usual", false, "List who you usually invite")
+	return cmd
+}
+
+func partnersTable(partners []Partner) tableData {
+	table := tableData{Headers: []string{"NAME", "GAMES", "LAST_PLAYED", "VENUES", "SHARED_SPEND", "LEVEL", "TREND"}}
+	for _, partner := range partners {
+		levels := make([]float64, 0, len(partner.Levels))
+		for _, point := range partner.Levels {
+			levels = append(levels, point.Level)
+		}
+		table.Rows = append(table.Rows, []string{
+			partner.Name,
+			fmt.Sprintf("%d", partner.Games),
+			partner.LastPlayed,
+			strings.Join(partner.Venues, ", "),
+			formatMoneyList(partner.SharedSpend),
+			levelLabel(partner.Level, partner.LevelChange),
+			levelSparkline(levels),
+		})
+	}
+	return table
+}
+
+func levelLabel(level, change float64) string {
+	if level == 0 {
+		return ""
+	}
+	if change == 0 {
+		return fmt.Sprintf("%.2f", level)
+	}
+	return fmt.Sprintf("%.2f (%+.2f)", level, change)
+}
+
+// levelSparkline charts levels relative to their own range, so that small
+// changes are visible.
+func levelSparkline(levels []float64) string {
+	if len(levels) < 2 {
+		return ""
+	}
+	low, high := levels[0], levels[0]
+	for _, level := range levels {
+		low = min(low, level)
+		high = max(high, level)
+	}
+	scaled := make([]float64, 0, len(levels))
+	for _, level := range levels {
+		if high == low {
+			scaled = append(scaled, 1)
+			continue
+		}
+		// Keep the lowest point visible instead of drawing it as a gap.
+		scaled = append(scaled, 1+(level-low)/(high-low)*7)
+	}
+	return sparkline(scaled)
+}
+
+// computePartners groups the stored rosters of booked matches that ended by
+// now by player, leaving out userID. Partners are ordered by games together, then by the
+// most recent game.
+func computePartners(bookings []storage.Booking, players []storage.MatchPlayer, userID string, now time.Time) []Partner {
+	bookingByID := map[string]storage.Booking{}
+	for _, booking := range bookings {
+		bookingByID[booking.ID] = booking
+	}
+
+	byUser := map[string]*Partner{}
+	venues := map[string]map[string]bool{}
+	spent := map[string][]api.Money{}
+	recentFrom := now.Add(-usualWindow).Format("2006-01-02")
+
+	for _, player := range players {
+		booking, ok := bookingByID[player.MatchID]
+		if !ok || player.UserID == "" || player.UserID == userID {
+			continue
+		}
+		// Games that have not ended yet were not played together yet.
+		if !bookingEnded(booking, now) {
+			continue
+		}
+		partner, ok := byUser[player.UserID]
+		if !ok {
+			partner = &Partner{UserID: player.UserID}
+			byUser[player.UserID] = partner
+			venues[player.UserID] = map[string]bool{}
+		}
+		partner.Name = player.Name
+		partner.Games++
+		if booking.Date >= recentFrom {
+			partner.RecentGames++
+		}
+		if booking.Date > partner.LastPlayed {
+			partner.LastPlayed = booking.Date
+		}
+		venues[player.UserID][booking.VenueName] = true
+		spent[player.UserID] = append(spent[player.UserID], bookingMoney(booking))
+		if player.LevelValue > 0 {
+			partner.Levels = append(partner.Levels, LevelPoint{Date: booking.Date, Level: player.LevelValue, Confidence: player.LevelConfidence})
+		}
+	}
+
+	partners := make([]Partner, 0, len(byUser))
+	for id, partner := range byUser {
+		for venue := range venues[id] {
+			if venue != "" {
+				partner.Venues = append(partner.Venues, venue)
+			}
+		}
+		sort.Strings(partner.Venues)
+		partner.SharedSpend = sumByCurrency(spent[id])
+		sort.SliceStable(partner.Levels, func(i, j int) bool {
+			return partner.Levels[i].Date < partner.Levels[j].Date
+		})
+		if len(partner.Levels) > 0 {
+			first := partner.Levels[0].Level
+			partner.Level = partner.Levels[len(partner.Levels)-1].Level
+			partner.LevelChange = roundCents(partner.Level - first)
+		}
+		partners = append(partners, *partner)
+	}
+	sort.Slice(partners, func(i, j int) bool {
+		if partners[i].Games != partners[j].Games {
+			return partners[i].Games > partners[j].Games
+		}
+		if partners[i].LastPlayed != partners[j].LastPlayed {
+			return partners[i].LastPlayed > partners[j].LastPlayed
+		}
+		return partners[i].Name < partners[j].Name
+	})
+	return partners
+}
+
+// usualInvitees ranks partners by recent games, then all games, dropping
+// those not played with recently.
+func usualInvitees(partners []Partner) []Partner {
+	usual := []Partner{}
+	for _, partner := range partners {
+		if partner.RecentGames > 0 {
+			usual = append(usual, partner)
+		}
+	}
+	sort.SliceStable(usual, func(i, j int) bool {
+		if usual[i].RecentGames != usual[j].RecentGames {
+			return usual[i].RecentGames > usual[j].RecentGames
+		}
+		return usual[i].Games > usual[j].Games
+	})
+	return usual
+}
+
+// inviteSuggestions returns up to n usual partners who are not yet in the
+// match. It is best effort: without stored rosters it returns nothing.
+func inviteSuggestions(details api.MatchDetails, userID string, n int) []string {
+	bookings, err := loadBookings(storage.BookingFilter{})
+	if err != nil {
+		return nil
+	}
+	players, err := loadMatchPlayers()
+	if err != nil {
+		return nil
+	}
+	inMatch := map[string]bool{}
+	for _, team := range details.Teams {
+		for _, player := range team.Players {
+			inMatch[player.UserID] = true
+		}
+	}
+
+	names := []string{}
+	for _, partner := range usualInvitees(computePartners(bookings, players, userID, time.Now())) {
+		if inMatch[partner.UserID] {
+			continue
+		}
+		names = append(names, partner.Name)
+		if len(names) == n {
+			break
+		}
+	}
+	return names
+}
+
+func loadMatchPlayers() ([]storage.MatchPlayer, error) {
+	db, err := storage.OpenBookingsDB()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return storage.ListMatchPlayers(db)
+}
+
+func currentUserID() (string, error) {
+	creds, err := storage.LoadCredentials()
+	if err != nil {
+		return "", err
+	}
+	if creds == nil || creds.UserID == "" {
+		return "", fmt.Errorf("not logged in. Run 'padel auth login' first")
+	}
+	return creds.UserID, nil
+}
+
+// syncRosters stores the players of matches whose roster has not been
+// fetched yet, and refetches those last fetched before the match ended, as
+// the roster and the levels Playtomic reports may still have changed.
+// Rosters are best effort: a failed fetch is reported and skipped.
+func syncRosters(ctx context.Context, db *sql.DB, matches []api.Match) (int, error) {
+	synced, err := storage.RosterSyncTimes(db)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	fetched := 0
+	requests := 0
+	for _, match := range matches {
+		if rosterFinal(match, synced[match.MatchID]) {
+			continue
+		}
+		if requests > 0 {
+			time.Sleep(rateLimitDelay)
+		}
+		requests++
+
+		details, err := client.GetMatchDetails(ctx, match.MatchID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: players of match %s unavailable: %v\n", match.MatchID, err)
+			continue
+		}
+		syncedAt := now.UTC().Format(time.RFC3339)
+		players := []storage.MatchPlayer{}
+		for _, team := range details.Teams {
+			for _, player := range team.Players {
+				if player.UserID == "" {
+					continue
+				}
+				players = append(players, storage.MatchPlayer{
+					MatchID:         match.MatchID,
+					UserID:          player.UserID,
+					Name:            player.Name,
+					TeamID:          team.TeamID,
+					LevelValue:      player.LevelValue,
+					LevelConfidence: player.LevelConfidence,
+					Organizer:       player.UserID == details.OwnerID,
+					SyncedAt:        syncedAt,
+				})
+			}
+		}
+		if err := storage.SaveMatchPlayers(db, match.MatchID, players); err != nil {
+			return fetched, err
+		}
+		fetched++
+	}
+	return fetched, nil
+}
+
+// rosterFinal reports whether a roster synced at syncedAt was fetched after
+// the match ended, so fetching it again would not change it.
+func rosterFinal(match api.Match, syncedAt string) bool {
+	synced, ok := parseAPIDateTime(syncedAt)
+	if !ok {
+		return false
+	}
+	end, ok := parseAPIDateTime(match.EndDate)
+	if !ok {
+		start, ok := parseAPIDateTime(match.StartDate)
+		if !ok {
+			return false
+		}
+		end = start.Add(storage.DefaultBookingDuration * time.Minute)
+	}
+	return !synced.Before(end)
+}
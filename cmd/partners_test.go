@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+)
+
+func TestComputePartners(t *testing.T) {
+	now := time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)
+	bookings := []storage.Booking{
+		{ID: "old", VenueName: "Blijdorp", Date: "2026-01-10", StartUTC: "2026-01-10T10:00:00Z", Duration: 90, Price: 40, Currency: "EUR"},
+		{ID: "recent", VenueName: "Blijdorp", Date: "2026-10-17", StartUTC: "2026-10-17T10:00:00Z", Duration: 90, Price: 44, Currency: "EUR"},
+		{ID: "sweden", VenueName: "PDL Stockholm", Date: "2026-10-20", StartUTC: "2026-10-20T17:00:00Z", Duration: 60, Price: 400, Currency: "SEK"},
+		// Started half an hour ago and still being played.
+		{ID: "playing", VenueName: "Kralingen", Date: "2026-10-24", StartUTC: "2026-10-24T11:30:00Z", Duration: 90, Price: 50, Currency: "EUR"},
+		{ID: "upcoming", VenueName: "Kralingen", Date: "2026-10-31", StartUTC: "2026-10-31T10:00:00Z", Duration: 90, Price: 50, Currency: "EUR"},
+		{ID: "no-start", VenueName: "Kralingen", Date: "2026-10-01", Price: 30, Currency: "EUR"},
+	}
+	roster := func(match string, players ...storage.MatchPlayer) []storage.MatchPlayer {
+		for i := range players {
+			players[i].MatchID = match
+		}
+		return players
+	}
+	me := storage.MatchPlayer{UserID: "me", Name: "Me", LevelValue: 3}
+	anna := func(level float64) storage.MatchPlayer {
+		return storage.MatchPlayer{UserID: "anna", Name: "Anna", LevelValue: level}
+	}
+	bob := storage.MatchPlayer{UserID: "bob", Name: "Bob", LevelValue: 2.5}
+	players := []storage.MatchPlayer{}
+	players = append(players, roster("old", me, anna(3.0), bob)...)
+	players = append(players, roster("recent", me, anna(3.2))...)
+	players = append(players, roster("sweden", me, anna(3.3))...)
+	players = append(players, roster("playing", me, bob)...)
+	players = append(players, roster("upcoming", me, bob, anna(3.4))...)
+	players = append(players, roster("no-start", me, bob)...)
+	players = append(players, roster("unknown-match", me, bob)...)
+
+	partners := computePartners(bookings, players, "me", now)
+	if len(partners) != 2 {
+		t.Fatalf("computePartners returned %d partners, want 2: %+v", len(partners), partners)
+	}
+
+	anne, bobby := partners[0], partners[1]
+	if anne.UserID != "anna" || bobby.UserID != "bob" {
+		t.Fatalf("partners ordered %s, %s; want anna, bob", anne.UserID, bobby.UserID)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "anna games", got: anne.Games, want: 3},
+		{name: "anna recent games", got: anne.RecentGames, want: 2},
+		{name: "anna last played", got: anne.LastPlayed, want: "2026-10-20"},
+		{name: "anna venues", got: anne.Venues, want: []string{"Blijdorp", "PDL Stockholm"}},
+		{name: "anna spend", got: anne.SharedSpend, want: []api.Money{{Amount: 84, Currency: "EUR"}, {Amount: 400, Currency: "SEK"}}},
+		{name: "anna level", got: anne.Level, want: 3.3},
+		{name: "anna level change", got: anne.LevelChange, want: 0.3},
+		{name: "anna level points", got: len(anne.Levels), want: 3},
+		{name: "bob games", got: bobby.Games, want: 1},
+		{name: "bob recent games", got: bobby.RecentGames, want: 0},
+		{name: "bob last played", got: bobby.LastPlayed, want: "2026-01-10"},
+		{name: "bob spend", got: bobby.SharedSpend, want: []api.Money{{Amount: 40, Currency: "EUR"}}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRosterFinal(t *testing.T) {
+	match := api.Match{StartDate: "2026-10-24T10:00:00", EndDate: "2026-10-24T11:30:00"}
+	tests := []struct {
+		name     string
+		match    api.Match
+		syncedAt string
+		want     bool
+	}{
+		{name: "never synced", match: match, want: false},
+		{name: "synced before the match", match: match, syncedAt: "2026-10-23T08:00:00Z", want: false},
+		{name: "synced during the match", match: match, syncedAt: "2026-10-24T11:00:00Z", want: false},
+		{name: "synced after the match", match: match, syncedAt: "2026-10-24T11:30:00Z", want: true},
+		{name: "no end date", match: api.Match{StartDate: "2026-10-24T10:00:00"}, syncedAt: "2026-10-24T11:00:00Z", want: false},
+		{name: "no end date, synced later", match: api.Match{StartDate: "2026-10-24T10:00:00"}, syncedAt: "2026-10-24T12:00:00Z", want: true},
+		{name: "unknown times", match: api.Match{}, syncedAt: "2026-10-24T12:00:00Z", want: false},
+	}
+	for _, tt := range tests {
+		if got := rosterFinal(tt.match, tt.syncedAt); got != tt.want {
+			t.Errorf("%s: rosterFinal = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
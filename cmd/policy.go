@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+)
+
+// Reasons given when a club has no slots for a day.
+const (
+	reasonPast        = "date is in the past"
+	reasonFullyBooked = "fully booked"
+	reasonNoMatch     = "no slots match the filters"
+)
+
+// defaultBookingOpensAt is when a new day becomes bookable if the club does
+// not say otherwise.
+const defaultBookingOpensAt = "00:00"
+
+// tenantPolicy extracts the opening hours and booking policy a tenant
+// publishes, or nil when it publishes neither.
+func tenantPolicy(tenant api.Tenant) *storage.VenuePolicy {
+	policy := storage.VenuePolicy{
+		MaxAdvanceDays:            tenant.BookingSettings.MaxAdvanceDays,
+		BookingOpensAt:            clockPrefix(tenant.BookingSettings.BookingOpensAt),
+		CancellationWindowMinutes: tenant.BookingSettings.CancellationWindowMinutes,
+	}
+	for day, hours := range tenant.OpeningHours {
+		if policy.OpeningHours == nil {
+			policy.OpeningHours = map[string]storage.OpeningHours{}
+		}
+		policy.OpeningHours[strings.ToUpper(day)] = storage.OpeningHours{
+			Open:  clockPrefix(hours.OpeningTime),
+			Close: clockPrefix(hours.ClosingTime),
+		}
+	}
+	if policy.OpeningHours == nil && policy.MaxAdvanceDays == 0 && policy.CancellationWindowMinutes == 0 {
+		return nil
+	}
+	return &policy
+}
+
+// resolvePolicy prefers what the tenant reports now and falls back to the
+// policy cached on the saved venue.
+func resolvePolicy(tenant api.Tenant, cached *storage.VenuePolicy) *storage.VenuePolicy {
+	if policy := tenantPolicy(tenant); policy != nil {
+		return policy
+	}
+	return cached
+}
+
+// explainNoSlots says why a club has no slots on day. rawSlots is how many
+// slots the club returned before filtering.
+func explainNoSlots(policy *storage.VenuePolicy, day, now time.Time, rawSlots int) string {
+	today := startOfDay(now.In(day.Location()))
+	if day.Before(today) {
+		return reasonPast
+	}
+	if policy != nil {
+		if dayClosed(*policy, day.Weekday()) {
+			return fmt.Sprintf("closed on %ss", day.Weekday())
+		}
+		if policy.MaxAdvanceDays > 0 {
+			opensAt := policy.BookingOpensAt
+			if opensAt == "" {
+				opensAt = defaultBookingOpensAt
+			}
+			minutes, err := parseClock(opensAt)
+			if err != nil {
+				minutes = 0
+			}
+			opens := day.AddDate(0, 0, -policy.MaxAdvanceDays).Add(time.Duration(minutes) * time.Minute)
+			if now.Before(opens) {
+				return fmt.Sprintf("not yet bookable, opens %s at %s", inDays(daysBetweenDates(today, startOfDay(opens))), opensAt)
+			}
+		}
+	}
+	if rawSlots > 0 {
+		return reasonNoMatch
+	}
+	return reasonFullyBooked
+}
+
+// dayClosed reports whether the club says it is closed on weekday. A weekday
+// missing from partly published hours is unknown rather than closed, and
+// equal opening and closing times mean open around the clock.
+func dayClosed(policy storage.VenuePolicy, weekday time.Weekday) bool {
+	hours, ok := policy.OpeningHours[strings.ToUpper(weekday.String())]
+	return ok && hours.Open == ""
+}
+
+func inDays(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return fmt.Sprintf("in %d days", days)
+}
+
+func daysBetweenDates(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24 + 0.5)
+}
+
+func countSlots(resources []api.AvailabilityResource) int {
+	count := 0
+	for _, resource := range resources {
+		count += len(resource.Slots)
+	}
+	return count
+}
+
+// clockPrefix trims "HH:MM:SS" to "HH:MM".
+func clockPrefix(value string) string {
+	if len(value) >= 5 {
+		return value[:5]
+	}
+	return value
+}
+
+func formatOpeningHours(policy storage.VenuePolicy) []string {
+	lines := []string{}
+	for weekday := time.Monday; ; weekday = (weekday + 1) % 7 {
+		hours, ok := policy.OpeningHours[strings.ToUpper(weekday.String())]
+		var label string
+		switch {
+		case !ok:
+			label = "unknown"
+		case dayClosed(policy, weekday):
+			label = "closed"
+		case hours.Open == hours.Close:
+			label = "open 24h"
+		default:
+			label = hours.Open + "-" + hours.Close
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", weekday.String()[:3], label))
+		if weekday == time.Sunday {
+			break
+		}
+	}
+	return lines
+}
+
+func noSlotsMessage(reason string) string {
+	if reason == "" {
+		return "No available slots."
+	}
+	return fmt.Sprintf("No available slots (%s).", reason)
+}
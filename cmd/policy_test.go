@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"padel-cli/storage"
+)
+
+func TestExplainNoSlotsOpeningHours(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		hours    map[string]storage.OpeningHours
+		rawSlots int
+		want     string
+	}{
+		{name: "open", hours: map[string]storage.OpeningHours{"SATURDAY": {Open: "08:00", Close: "23:00"}}, want: reasonFullyBooked},
+		{name: "explicitly closed", hours: map[string]storage.OpeningHours{"SATURDAY": {}}, want: "closed on Saturdays"},
+		// Only weekdays were published, so Saturday is unknown.
+		{name: "missing weekday", hours: map[string]storage.OpeningHours{"MONDAY": {Open: "08:00", Close: "23:00"}}, want: reasonFullyBooked},
+		{name: "missing weekday with slots", hours: map[string]storage.OpeningHours{"MONDAY": {Open: "08:00", Close: "23:00"}}, rawSlots: 3, want: reasonNoMatch},
+		// Equal opening and closing times mean open around the clock.
+		{name: "open all day", hours: map[string]storage.OpeningHours{"SATURDAY": {Open: "00:00", Close: "00:00"}}, want: reasonFullyBooked},
+	}
+	for _, tt := range tests {
+		policy := &storage.VenuePolicy{OpeningHours: tt.hours}
+		if got := explainNoSlots(policy, saturday, now, tt.rawSlots); got != tt.want {
+			t.Errorf("%s: explainNoSlots = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatOpeningHours(t *testing.T) {
+	policy := storage.VenuePolicy{OpeningHours: map[string]storage.OpeningHours{
+		"MONDAY":   {Open: "08:00", Close: "23:00"},
+		"TUESDAY":  {Open: "00:00", Close: "00:00"},
+		"SATURDAY": {},
+	}}
+	lines := formatOpeningHours(policy)
+	want := map[int]string{0: "Mon 08:00-23:00", 1: "Tue open 24h", 2: "Wed unknown", 5: "Sat closed"}
+	for idx, line := range want {
+		if lines[idx] != line {
+			t.Errorf("line %d = %q, want %q", idx, lines[idx], line)
+		}
+	}
+}
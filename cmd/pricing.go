@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"padel-cli/api"
+)
+
+const defaultPlayers = 4
+
+// applySlotPricing fills in the per-player and per-hour figures for each slot
+// from its quoted court price.
+func applySlotPricing(slots []AvailabilitySlot, players int) []AvailabilitySlot {
+	if players <= 0 {
+		players = defaultPlayers
+	}
+	for i := range slots {
+		slots[i].Players = players
+		if slots[i].Price == "" {
+			continue
+		}
+		price, err := parsePrice(slots[i].Price, slots[i].VenueTimezone)
+		if err != nil {
+			// Leave the slot unpriced rather than free.
+			continue
+		}
+		slots[i].PriceAmount = price.Amount
+		slots[i].Currency = price.Currency
+		slots[i].PricePerPlayer = roundCents(price.Amount / float64(players))
+		if slots[i].Duration > 0 {
+			slots[i].PricePerHour = roundCents(price.Amount * 60 / float64(slots[i].Duration))
+		}
+	}
+	return slots
+}
+
+// slotPriced reports whether the slot has a price that was understood.
+// Price texts that could not be parsed are kept for display but leave the
+// slot without a currency.
+func slotPriced(slot AvailabilitySlot) bool {
+	return slot.Currency != ""
+}
+
+// parsePriceLimit parses a --max-price style limit such as "40", "40EUR" or
+// "400 SEK". A bare amount is in the budget currency, EUR unless configured.
+func parsePriceLimit(flag, input string) (api.Money, error) {
+	if strings.TrimSpace(input) == "" {
+		return api.Money{}, nil
+	}
+	limit, err := api.ParseMoneyFormat(input, moneyFormat(""))
+	if err != nil {
+		return api.Money{}, fmt.Errorf("invalid %s %q (expected an amount, optionally with a currency such as 40EUR)", flag, input)
+	}
+	if limit.Amount < 0 {
+		return api.Money{}, fmt.Errorf("%s must not be negative", flag)
+	}
+	if limit.Currency == "" {
+		limit.Currency = cfg.Budget.currency()
+	}
+	return limit, nil
+}
+
+// filterSlotsByPrice drops slots above the given limits. A zero limit is
+// ignored, and slots without a known price are kept. Prices in another
+// currency than the limit cannot be compared, so those slots are dropped.
+func filterSlotsByPrice(slots []AvailabilitySlot, maxPrice, maxPerPlayer api.Money) []AvailabilitySlot {
+	if maxPrice.Amount <= 0 && maxPerPlayer.Amount <= 0 {
+		return slots
+	}
+	filtered := make([]AvailabilitySlot, 0, len(slots))
+	for _, slot := range slots {
+		if slotPriced(slot) {
+			if !withinLimit(slot.PriceAmount, slot.Currency, maxPrice) {
+				continue
+			}
+			if !withinLimit(slot.PricePerPlayer, slot.Currency, maxPerPlayer) {
+				continue
+			}
+		}
+		filtered = append(filtered, slot)
+	}
+	return filtered
+}
+
+func withinLimit(amount float64, currency string, limit api.Money) bool {
+	if limit.Amount <= 0 {
+		return true
+	}
+	return strings.EqualFold(currency, limit.Currency) && amount <= limit.Amount
+}
+
+// sortSlotsByPrice orders slots cheapest first within each currency;
+// currencies are grouped by code and unknown prices sort last.
+func sortSlotsByPrice(slots []AvailabilitySlot) {
+	sort.SliceStable(slots, func(i, j int) bool {
+		if cmp := comparePrices(slots[i], slots[j]); cmp != 0 {
+			return cmp < 0
+		}
+		if slots[i].Time != slots[j].Time {
+			return slots[i].Time < slots[j].Time
+		}
+		return slots[i].Court < slots[j].Court
+	})
+}
+
+// comparePrices orders two slots by currency code, then by price. Slots
+// without a price come after priced ones.
+func comparePrices(left, right AvailabilitySlot) int {
+	if slotPriced(left) != slotPriced(right) {
+		if !slotPriced(left) {
+			return 1
+		}
+		return -1
+	}
+	if !slotPriced(left) {
+		return 0
+	}
+	if left.Currency != right.Currency {
+		return strings.Compare(left.Currency, right.Currency)
+	}
+	switch {
+	case left.PriceAmount < right.PriceAmount:
+		return -1
+	case left.PriceAmount > right.PriceAmount:
+		return 1
+	}
+	return 0
+}
+
+// cheapestSlot returns the lowest priced slot. Amounts are only compared
+// within a currency, so with several currencies the first in code order
+// wins.
+func cheapestSlot(slots []AvailabilitySlot) (AvailabilitySlot, bool) {
+	var cheapest AvailabilitySlot
+	found := false
+	for _, slot := range slots {
+		if !slotPriced(slot) {
+			continue
+		}
+		if !found || comparePrices(slot, cheapest) < 0 {
+			cheapest = slot
+			found = true
+		}
+	}
+	return cheapest, found
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func formatPerPlayer(slot AvailabilitySlot) string {
+	if !slotPriced(slot) {
+		return ""
+	}
+	return formatMoney(api.Money{Amount: slot.PricePerPlayer, Currency: slot.Currency})
+}
+
+func formatPerHour(slot AvailabilitySlot) string {
+	if !slotPriced(slot) || slot.Duration <= 0 {
+		return ""
+	}
+	return formatMoney(api.Money{Amount: slot.PricePerHour, Currency: slot.Currency})
+}
+
+func plainAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
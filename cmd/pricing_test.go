@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"padel-cli/api"
+)
+
+func pricedSlot(court string, amount float64, currency string) AvailabilitySlot {
+	return AvailabilitySlot{Court: court, Time: "10:00", Price: "x", PriceAmount: amount, PricePerPlayer: amount / 4, Currency: currency}
+}
+
+func slotCourts(slots []AvailabilitySlot) []string {
+	courts := []string{}
+	for _, slot := range slots {
+		courts = append(courts, slot.Court)
+	}
+	return courts
+}
+
+func TestSortSlotsByPriceGroupsCurrencies(t *testing.T) {
+	slots := []AvailabilitySlot{
+		pricedSlot("sek-400", 400, "SEK"),
+		{Court: "unpriced", Time: "10:00"},
+		pricedSlot("eur-30", 30, "EUR"),
+		pricedSlot("sek-40", 40, "SEK"),
+		pricedSlot("eur-25", 25, "EUR"),
+	}
+	sortSlotsByPrice(slots)
+
+	want := []string{"eur-25", "eur-30", "sek-40", "sek-400", "unpriced"}
+	if got := slotCourts(slots); !reflect.DeepEqual(got, want) {
+		t.Errorf("sortSlotsByPrice order = %v, want %v", got, want)
+	}
+}
+
+func TestFilterSlotsByPriceComparesWithinCurrency(t *testing.T) {
+	slots := []AvailabilitySlot{
+		pricedSlot("eur-30", 30, "EUR"),
+		pricedSlot("eur-50", 50, "EUR"),
+		pricedSlot("sek-40", 40, "SEK"),
+		{Court: "unpriced", Time: "10:00"},
+	}
+
+	tests := []struct {
+		name         string
+		maxPrice     api.Money
+		maxPerPlayer api.Money
+		want         []string
+	}{
+		{name: "no limits", want: []string{"eur-30", "eur-50", "sek-40", "unpriced"}},
+		{name: "eur court price", maxPrice: api.Money{Amount: 40, Currency: "EUR"}, want: []string{"eur-30", "unpriced"}},
+		{name: "sek court price", maxPrice: api.Money{Amount: 40, Currency: "SEK"}, want: []string{"sek-40", "unpriced"}},
+		{name: "eur per player", maxPerPlayer: api.Money{Amount: 10, Currency: "EUR"}, want: []string{"eur-30", "unpriced"}},
+	}
+	for _, tt := range tests {
+		got := slotCourts(filterSlotsByPrice(slots, tt.maxPrice, tt.maxPerPlayer))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: filterSlotsByPrice = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSortClubsByPrice(t *testing.T) {
+	clubs := []SearchClubResult{
+		{ClubName: "empty"},
+		{ClubName: "sek", Slots: []AvailabilitySlot{pricedSlot("a", 40, "SEK")}},
+		{ClubName: "eur-high", Slots: []AvailabilitySlot{pricedSlot("a", 35, "EUR"), pricedSlot("b", 45, "EUR")}},
+		{ClubName: "eur-low", Slots: []AvailabilitySlot{pricedSlot("a", 30, "EUR")}},
+	}
+	sortClubsByPrice(clubs)
+
+	got := []string{}
+	for _, club := range clubs {
+		got = append(got, club.ClubName)
+	}
+	want := []string{"eur-low", "eur-high", "sek", "empty"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortClubsByPrice order = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// bookingQuery holds the structured filters of bookings list. They are
+// turned into a storage.BookingFilter and evaluated in SQL.
+type bookingQuery struct {
+	Venues   string
+	Courts   string
+	Weekdays string
+	Sources  string
+	MinPrice string
+	MaxPrice string
+	Search   string
+	Sort     string
+	Desc     bool
+	Limit    int
+}
+
+func addBookingQueryFlags(cmd *cobra.Command, query *bookingQuery) {
+	cmd.Flags().StringVar(&query.Venues, "venue", "", "Only bookings at these venues (alias, @group or part of the name; comma-separated)")
+	cmd.Flags().StringVar(&query.Courts, "court", "", "Only bookings on these courts (comma-separated)")
+	cmd.Flags().StringVar(&query.Weekdays, "weekday", "", "Only bookings on these weekdays (e.g. sat,sun)")
+	cmd.Flags().StringVar(&query.Sources, "source", "", "Only bookings from these sources (manual, playtomic_sync, cli_booked, import)")
+	cmd.Flags().StringVar(&query.MinPrice, "min-price", "", "Only bookings costing at least this much (e.g. 40 or 400SEK)")
+	cmd.Flags().StringVar(&query.MaxPrice, "max-price", "", "Only bookings costing at most this much (e.g. 40 or 400SEK)")
+	cmd.Flags().StringVar(&query.Search, "search", "", "Only bookings whose venue, court or ID contains this text")
+	cmd.Flags().StringVar(&query.Sort, "sort", "date", "Sort by "+strings.Join(storage.BookingSorts, "|"))
+	cmd.Flags().BoolVar(&query.Desc, "desc", false, "Reverse the sort order")
+	cmd.Flags().IntVar(&query.Limit, "limit", 0, "Show at most this many bookings")
+}
+
+func (q bookingQuery) filter() (storage.BookingFilter, error) {
+	filter := storage.BookingFilter{
+		Search:  strings.TrimSpace(q.Search),
+		Sort:    strings.ToLower(strings.TrimSpace(q.Sort)),
+		Desc:    q.Desc,
+		Limit:   q.Limit,
+		Courts:  splitAliases(q.Courts),
+		Sources: splitAliases(strings.ToLower(q.Sources)),
+	}
+	if q.Limit < 0 {
+		return filter, fmt.Errorf("--limit must not be negative")
+	}
+	minPrice, err := parsePriceLimit("--min-price", q.MinPrice)
+	if err != nil {
+		return filter, err
+	}
+	maxPrice, err := parsePriceLimit("--max-price", q.MaxPrice)
+	if err != nil {
+		return filter, err
+	}
+	if minPrice.Amount > 0 && maxPrice.Amount > 0 {
+		if !strings.EqualFold(minPrice.Currency, maxPrice.Currency) {
+			return filter, fmt.Errorf("--min-price and --max-price must be in the same currency")
+		}
+		if minPrice.Amount > maxPrice.Amount {
+			return filter, fmt.Errorf("--min-price must not be above --max-price")
+		}
+	}
+	filter.MinPrice, filter.MaxPrice = minPrice.Amount, maxPrice.Amount
+	filter.PriceCurrency = minPrice.Currency
+	if maxPrice.Amount > 0 {
+		filter.PriceCurrency = maxPrice.Currency
+	}
+	valid := false
+	for _, name := range storage.BookingSorts {
+		if filter.Sort == name {
+			valid = true
+		}
+	}
+	if !valid {
+		return filter, fmt.Errorf("unknown --sort %q (expected %s)", q.Sort, strings.Join(storage.BookingSorts, "|"))
+	}
+
+	for _, name := range splitAliases(strings.ToLower(q.Weekdays)) {
+		weekday, ok := weekdayNames[name]
+		if !ok {
+			return filter, fmt.Errorf("unknown weekday %q", name)
+		}
+		filter.Weekdays = append(filter.Weekdays, weekday)
+	}
+
+	venues, err := venueFilterValues(q.Venues)
+	if err != nil {
+		return filter, err
+	}
+	filter.Venues = venues
+	return filter, nil
+}
+
+// venueFilterValues expands a comma-separated venue list for
+// storage.BookingFilter.Venues. Saved venues and @groups are matched by alias
+// and ID; anything else is matched against the venue name, so venues that
+// were never saved can still be found.
+func venueFilterValues(input string) ([]string, error) {
+	values := []string{}
+	for _, value := range splitAliases(input) {
+		if strings.HasPrefix(value, "@") {
+			venues, err := lookupVenues([]string{value})
+			if err != nil {
+				return nil, err
+			}
+			for _, venue := range venues {
+				values = append(values, venue.Alias, venue.ID)
+			}
+			continue
+		}
+		if venue, err := lookupVenue(value); err == nil {
+			values = append(values, venue.Alias, venue.ID)
+			continue
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -19,10 +19,16 @@ var (
 )
 
 type Config struct {
-	DefaultLocation   string          `json:"default_location"`
-	FavouriteClubs    []FavouriteClub `json:"favourite_clubs"`
-	PreferredTimes    []string        `json:"preferred_times"`
-	PreferredDuration int             `json:"preferred_duration"`
+	DefaultLocation   string            `json:"default_location"`
+	FavouriteClubs    []FavouriteClub   `json:"favourite_clubs"`
+	PreferredTimes    []string          `json:"preferred_times"`
+	PreferredDuration int               `json:"preferred_duration"`
+	Locale            string            `json:"locale"`
+	Suggest           SuggestConfig     `json:"suggest"`
+	Routing           RoutingConfig     `json:"routing"`
+	Geocoder          GeocoderConfig    `json:"geocoder"`
+	Locations         map[string]string `json:"locations"`
+	Budget            BudgetConfig      `json:"budget"`
 }
 
 type FavouriteClub struct {
@@ -34,10 +40,7 @@ var rootCmd = &cobra.Command{
 	Use:   "padel",
 	Short: "Padel CLI for Playtomic availability",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		if outputJSON && outputCompact {
-			return fmt.Errorf("choose either --json or --compact")
-		}
-		return nil
+		return resolveOutputFormat()
 	},
 	SilenceUsage: true,
 }
@@ -47,10 +50,15 @@ func Execute() {
 	rootCmd.AddCommand(clubsCmd())
 	rootCmd.AddCommand(availabilityCmd())
 	rootCmd.AddCommand(searchCmd())
+	rootCmd.AddCommand(suggestCmd())
 	rootCmd.AddCommand(venuesCmd())
 	rootCmd.AddCommand(bookingsCmd())
 	rootCmd.AddCommand(authCmd())
 	rootCmd.AddCommand(bookCmd())
+	rootCmd.AddCommand(serveCmd())
+	rootCmd.AddCommand(budgetCmd())
+	rootCmd.AddCommand(partnersCmd())
+	rootCmd.AddCommand(levelCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -60,6 +68,8 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&outputJSON, "json", false, "Output JSON")
 	rootCmd.PersistentFlags().BoolVar(&outputCompact, "compact", false, "Output compact text")
+	rootCmd.PersistentFlags().StringVarP(&outputName, "output", "o", "", "Output format (table|json|ndjson|csv|markdown|compact|template|whatsapp|telegram-markdown|slack-mrkdwn)")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go text/template used with --output template")
 }
 
 func initConfig() {
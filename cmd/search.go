@@ -8,14 +8,18 @@ import (
 	"time"
 
 	"padel-cli/api"
+	"padel-cli/storage"
 
 	"github.com/spf13/cobra"
 )
 
 type SearchClubResult struct {
-	ClubID   string             `json:"club_id"`
-	ClubName string             `json:"club_name"`
-	Slots    []AvailabilitySlot `json:"slots"`
+	ClubID        string             `json:"club_id"`
+	ClubName      string             `json:"club_name"`
+	DistanceKm    *float64           `json:"distance_km,omitempty"`
+	TravelMinutes int                `json:"travel_minutes,omitempty"`
+	Slots         []AvailabilitySlot `json:"slots"`
+	Reason        string             `json:"reason,omitempty"`
 }
 
 type SearchResult struct {
@@ -24,8 +28,13 @@ type SearchResult struct {
 }
 
 type searchTenant struct {
-	Tenant   api.Tenant
-	TimeZone string
+	Tenant        api.Tenant
+	TimeZone      string
+	Alias         string
+	Rules         *storage.CourtRules
+	Policy        *storage.VenuePolicy
+	DistanceKm    *float64
+	TravelMinutes int
 }
 
 const rateLimitDelay = 150 * time.Millisecond
@@ -35,11 +44,15 @@ func searchCmd() *cobra.Command {
 	var clubID string
 	var venuesInput string
 	var date string
-	var timeRange string
 	var weekend bool
 	var radius int
-	var showOutdoor bool
-	var showAll bool
+	var filter slotFilter
+	var players int
+	var maxPriceInput string
+	var maxPerPlayerInput string
+	var sortBy string
+	var groups groupOptions
+	var maxDistance float64
 
 	cmd := &cobra.Command{
 		Use:   "search",
@@ -48,8 +61,29 @@ func searchCmd() *cobra.Command {
 			if clubID != "" && venuesInput != "" {
 				return fmt.Errorf("use either --club-id or --venues, not both")
 			}
-			if showOutdoor && showAll {
-				return fmt.Errorf("use either --outdoor or --all, not both")
+			if err := filter.validate(); err != nil {
+				return err
+			}
+			if err := groups.validate(); err != nil {
+				return err
+			}
+			sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+			if sortBy == "" {
+				sortBy = "name"
+				if venuesInput != "" {
+					sortBy = "priority"
+				}
+			}
+			if sortBy != "name" && sortBy != "priority" && sortBy != "price" && sortBy != "distance" {
+				return fmt.Errorf("invalid --sort %q (expected name|priority|price|distance)", sortBy)
+			}
+			maxPrice, err := parsePriceLimit("--max-price", maxPriceInput)
+			if err != nil {
+				return err
+			}
+			maxPricePerPlayer, err := parsePriceLimit("--max-price-per-player", maxPerPlayerInput)
+			if err != nil {
+				return err
 			}
 			if clubID == "" && venuesInput == "" {
 				if location == "" {
@@ -60,34 +94,41 @@ func searchCmd() *cobra.Command {
 				}
 			}
 
-			var dateInputs []string
+			dateExpr := date
 			if weekend {
-				for _, d := range nextWeekendDates(time.Now()) {
-					dateInputs = append(dateInputs, d.Format("2006-01-02"))
-				}
-			} else {
-				if date == "" {
-					return fmt.Errorf("--date is required unless --weekend is set")
+				if date != "" {
+					return fmt.Errorf("use either --date or --weekend, not both")
 				}
-				parsed, err := parseDateInput(date)
+				dateExpr = "weekend"
+			}
+			if dateExpr == "" {
+				return fmt.Errorf("--date is required unless --weekend is set")
+			}
+			// Validate the expression up front; it is evaluated in the venue
+			// timezone once the venues are known.
+			if _, err := parseDateRangeInLocation(dateExpr, time.Local); err != nil {
+				return err
+			}
+
+			if (maxDistance > 0 || sortBy == "distance") && location == "" {
+				return fmt.Errorf("--location is required to filter or sort by distance")
+			}
+
+			ctx := context.Background()
+			var meter *distanceMeter
+			var lat, lon float64
+			if location != "" {
+				var err error
+				lat, lon, err = resolveLocation(ctx, location)
 				if err != nil {
 					return err
 				}
-				dateInputs = []string{parsed.Format("2006-01-02")}
-			}
-
-			var startMinutes, endMinutes int
-			var hasTimeRange bool
-			if timeRange != "" {
-				parsedStart, parsedEnd, err := parseTimeRange(timeRange)
+				meter, err = newDistanceMeter(lat, lon)
 				if err != nil {
 					return err
 				}
-				startMinutes, endMinutes = parsedStart, parsedEnd
-				hasTimeRange = true
 			}
 
-			ctx := context.Background()
 			var tenants []searchTenant
 			if clubID != "" {
 				tenant, err := client.GetTenant(ctx, clubID)
@@ -103,32 +144,12 @@ func searchCmd() *cobra.Command {
 				if len(aliases) == 0 {
 					return fmt.Errorf("--venues must include at least one alias")
 				}
-				venues, err := lookupVenues(aliases)
+				venueTenants, err := loadVenueTenants(ctx, aliases)
 				if err != nil {
 					return err
 				}
-				for idx, venue := range venues {
-					tenant, err := client.GetTenant(ctx, venue.ID)
-					if err != nil {
-						return err
-					}
-					venueTimezone := venue.TimeZone
-					if venueTimezone == "" {
-						venueTimezone = tenant.Address.TimeZone
-					}
-					tenants = append(tenants, searchTenant{
-						Tenant:   tenant,
-						TimeZone: normalizeVenueTimezone(venueTimezone),
-					})
-					if idx < len(venues)-1 {
-						time.Sleep(rateLimitDelay)
-					}
-				}
+				tenants = venueTenants
 			} else {
-				lat, lon, err := resolveLocation(ctx, location)
-				if err != nil {
-					return err
-				}
 				rawTenants, err := client.GetTenants(ctx, lat, lon, radius)
 				if err != nil {
 					return err
@@ -141,81 +162,198 @@ func searchCmd() *cobra.Command {
 				}
 			}
 
-			sort.Slice(tenants, func(i, j int) bool {
-				return tenants[i].Tenant.TenantName < tenants[j].Tenant.TenantName
-			})
-
-			results := make([]SearchResult, 0, len(dateInputs))
-			for _, dateInput := range dateInputs {
-
-				clubResults := make([]SearchClubResult, 0, len(tenants))
-				for idx, tenantInfo := range tenants {
-					location := venueLocation(tenantInfo.TimeZone)
-					target, err := parseDateInputInLocation(dateInput, location)
-					if err != nil {
-						return err
-					}
-					startLocal := time.Date(target.Year(), target.Month(), target.Day(), 0, 0, 0, 0, location)
-					endLocal := time.Date(target.Year(), target.Month(), target.Day(), 23, 59, 59, 0, location)
-					startUTC := startLocal.UTC()
-					endUTC := endLocal.UTC()
-
-					availability, err := client.GetAvailability(ctx, tenantInfo.Tenant.TenantID, startUTC, endUTC)
-					if err != nil {
-						return err
-					}
+			if meter != nil {
+				tenants = measureTenants(ctx, meter, tenants, maxDistance)
+			}
+			// Saved venues keep the order they were given in (their
+			// priority) unless another order is asked for; other clubs are
+			// listed by name.
+			if sortBy == "name" || venuesInput == "" {
+				sort.SliceStable(tenants, func(i, j int) bool {
+					return tenants[i].Tenant.TenantName < tenants[j].Tenant.TenantName
+				})
+			}
+			if sortBy == "distance" {
+				sort.SliceStable(tenants, func(i, j int) bool {
+					return compareDistance(tenants[i].DistanceKm, tenants[j].DistanceKm) < 0
+				})
+			}
 
-					// Fetch resources to get indoor/outdoor info
-					resources, err := client.GetResources(ctx, tenantInfo.Tenant.TenantID)
-					if err != nil {
-						// Fall back to tenant resources if GetResources fails
-						resources = tenantInfo.Tenant.Resources
-					}
+			dateLocation := time.Local
+			if len(tenants) > 0 {
+				dateLocation = venueLocation(tenants[0].TimeZone)
+			}
+			days, err := parseDateRangeInLocation(dateExpr, dateLocation)
+			if err != nil {
+				return err
+			}
+			dateInputs := formatDays(days)
 
-					resourceInfo := map[string]api.Resource{}
-					for _, resource := range resources {
-						resourceInfo[resource.ResourceID] = resource
+			results, err := fetchSearchResults(ctx, tenants, dateInputs, groups.fetchFilter(filter), players)
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				for idx := range result.Clubs {
+					club := &result.Clubs[idx]
+					club.Slots = filterSlotsByPrice(club.Slots, maxPrice, maxPricePerPlayer)
+					if len(club.Slots) == 0 && club.Reason == "" {
+						club.Reason = reasonNoMatch
 					}
-
-					targetDate := target.Format("2006-01-02")
-					slots := filterAvailabilityWithResources(availability, resourceInfo, startMinutes, endMinutes, hasTimeRange, targetDate, tenantInfo.TimeZone, showOutdoor, showAll)
-					clubResults = append(clubResults, SearchClubResult{
-						ClubID:   tenantInfo.Tenant.TenantID,
-						ClubName: tenantInfo.Tenant.TenantName,
-						Slots:    slots,
-					})
-
-					if idx < len(tenants)-1 {
-						time.Sleep(rateLimitDelay)
+					if sortBy == "price" {
+						sortSlotsByPrice(club.Slots)
 					}
 				}
-
-				results = append(results, SearchResult{
-					Date:  dateInput,
-					Clubs: clubResults,
-				})
+				if sortBy == "price" {
+					sortClubsByPrice(result.Clubs)
+				}
 			}
 
-			if outputJSON {
-				return writeJSON(results)
+			if groups.enabled() {
+				return render(slotGroupsSpec(searchGroups(results, groups, filter)))
 			}
-
-			return renderSearch(results)
+			return render(searchSpec(results, sortBy == "price"))
 		},
 	}
 
-	cmd.Flags().StringVar(&location, "location", "", "Location name or lat,lon")
+	cmd.Flags().StringVar(&location, "location", "", "Saved location name, place name or lat,lon")
 	cmd.Flags().StringVar(&clubID, "club-id", "", "Club (tenant) ID")
-	cmd.Flags().StringVar(&venuesInput, "venues", "", "Comma-separated saved venue aliases")
-	cmd.Flags().StringVar(&date, "date", "", "Date (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&timeRange, "time", "", "Time range (HH:MM-HH:MM)")
+	cmd.Flags().StringVar(&venuesInput, "venues", "", "Comma-separated saved venue aliases or @group, in priority order")
+	cmd.Flags().StringVar(&date, "date", "", "Date or date expression (YYYY-MM-DD, sat, next saturday, +3d, this week, A..B, every sat in nov)")
+	addTimeWindowFlags(cmd, &filter)
 	cmd.Flags().BoolVar(&weekend, "weekend", false, "Search the next Saturday and Sunday")
 	cmd.Flags().IntVar(&radius, "radius", 50000, "Search radius in meters")
-	cmd.Flags().BoolVar(&showOutdoor, "outdoor", false, "Show only outdoor courts")
-	cmd.Flags().BoolVar(&showAll, "all", false, "Show all courts (indoor and outdoor)")
+	cmd.Flags().BoolVar(&filter.ShowOutdoor, "outdoor", false, "Show only outdoor courts")
+	cmd.Flags().BoolVar(&filter.ShowAll, "all", false, "Show all courts (indoor and outdoor)")
+	addSlotFilterFlags(cmd, &filter)
+	cmd.Flags().IntVar(&players, "players", defaultPlayers, "Number of players to split the price between")
+	cmd.Flags().StringVar(&maxPriceInput, "max-price", "", "Only show slots costing at most this much per court (e.g. 40 or 400SEK; a bare amount is in the budget currency, EUR by default)")
+	cmd.Flags().StringVar(&maxPerPlayerInput, "max-price-per-player", "", "Only show slots costing at most this much per player (same format as --max-price)")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort clubs by name, priority, price or distance (default priority with --venues, else name; price also sorts slots)")
+	addGroupFlags(cmd, &groups)
+	cmd.Flags().Float64Var(&maxDistance, "max-distance", 0, "Only search clubs within this many km of --location")
 	return cmd
 }
 
+// loadVenueTenants resolves saved venue aliases to their tenants, keeping the
+// order the aliases were given in.
+func loadVenueTenants(ctx context.Context, aliases []string) ([]searchTenant, error) {
+	venues, err := lookupVenues(aliases)
+	if err != nil {
+		return nil, err
+	}
+	tenants := make([]searchTenant, 0, len(venues))
+	for idx, venue := range venues {
+		tenant, err := client.GetTenant(ctx, venue.ID)
+		if err != nil {
+			return nil, err
+		}
+		venueTimezone := venue.TimeZone
+		if venueTimezone == "" {
+			venueTimezone = tenant.Address.TimeZone
+		}
+		tenants = append(tenants, searchTenant{
+			Tenant:   tenant,
+			TimeZone: normalizeVenueTimezone(venueTimezone),
+			Alias:    venue.Alias,
+			Rules:    venue.CourtRules,
+			Policy:   venue.Policy,
+		})
+		if idx < len(venues)-1 {
+			time.Sleep(rateLimitDelay)
+		}
+	}
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("no venues match %s", strings.Join(aliases, ", "))
+	}
+	return tenants, nil
+}
+
+// measureTenants fills in the distance and travel time of each tenant and
+// drops those further than maxDistance km (when set).
+func measureTenants(ctx context.Context, meter *distanceMeter, tenants []searchTenant, maxDistance float64) []searchTenant {
+	measured := make([]searchTenant, 0, len(tenants))
+	for _, tenant := range tenants {
+		distance := meter.distanceKm(tenant.Tenant.Address.Coord)
+		if !withinDistance(distance, maxDistance) {
+			continue
+		}
+		tenant.DistanceKm = distance
+		tenant.TravelMinutes = meter.travelMinutes(ctx, tenant.Tenant.Address.Coord)
+		measured = append(measured, tenant)
+	}
+	return measured
+}
+
+// fetchSearchResults loads the filtered and priced availability of every
+// tenant on every date, one result per date.
+func fetchSearchResults(ctx context.Context, tenants []searchTenant, dateInputs []string, filter slotFilter, players int) ([]SearchResult, error) {
+	results := make([]SearchResult, 0, len(dateInputs))
+	for _, dateInput := range dateInputs {
+		clubResults := make([]SearchClubResult, 0, len(tenants))
+		for idx, tenantInfo := range tenants {
+			location := venueLocation(tenantInfo.TimeZone)
+			target, err := parseDateInputInLocation(dateInput, location)
+			if err != nil {
+				return nil, err
+			}
+			availability, err := fetchDayAvailability(ctx, tenantInfo.Tenant.TenantID, target, location, filter)
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch resources to get indoor/outdoor info
+			resources, err := client.GetResources(ctx, tenantInfo.Tenant.TenantID)
+			if err != nil {
+				// Fall back to tenant resources if GetResources fails
+				resources = tenantInfo.Tenant.Resources
+			}
+
+			resourceInfo := map[string]api.Resource{}
+			for _, resource := range resources {
+				resourceInfo[resource.ResourceID] = resource
+			}
+
+			tenantFilter := filter
+			tenantFilter.Rules = tenantInfo.Rules
+			targetDate := target.Format("2006-01-02")
+			slots := filterAvailabilityWithResources(availability, resourceInfo, targetDate, tenantInfo.TimeZone, tenantFilter)
+			slots = applySlotPricing(slots, players)
+			club := SearchClubResult{
+				ClubID:        tenantInfo.Tenant.TenantID,
+				ClubName:      tenantInfo.Tenant.TenantName,
+				DistanceKm:    tenantInfo.DistanceKm,
+				TravelMinutes: tenantInfo.TravelMinutes,
+				Slots:         slots,
+			}
+			if len(slots) == 0 {
+				club.Reason = explainNoSlots(resolvePolicy(tenantInfo.Tenant, tenantInfo.Policy), target, time.Now(), countSlots(availability))
+			}
+			clubResults = append(clubResults, club)
+
+			if idx < len(tenants)-1 {
+				time.Sleep(rateLimitDelay)
+			}
+		}
+		results = append(results, SearchResult{
+			Date:  dateInput,
+			Clubs: clubResults,
+		})
+	}
+	return results, nil
+}
+
+// sortClubsByPrice orders clubs by their cheapest slot, grouping clubs that
+// charge in the same currency; clubs without priced slots keep their
+// relative order at the end.
+func sortClubsByPrice(clubs []SearchClubResult) {
+	sort.SliceStable(clubs, func(i, j int) bool {
+		left, _ := cheapestSlot(clubs[i].Slots)
+		right, _ := cheapestSlot(clubs[j].Slots)
+		return comparePrices(left, right) < 0
+	})
+}
+
 func splitAliases(input string) []string {
 	parts := strings.Split(input, ",")
 	aliases := make([]string, 0, len(parts))
@@ -235,10 +373,14 @@ func filterAvailability(resources []api.AvailabilityResource, resourceNames map[
 	for id, name := range resourceNames {
 		resourceInfo[id] = api.Resource{ResourceID: id, Name: name}
 	}
-	return filterAvailabilityWithResources(resources, resourceInfo, startMinutes, endMinutes, hasTimeRange, targetDate, venueTimezone, false, true)
+	filter := slotFilter{ShowAll: true, TimeMode: timeModeStart}
+	if hasTimeRange {
+		filter.Windows = []timeWindow{{Start: startMinutes, End: endMinutes}}
+	}
+	return filterAvailabilityWithResources(resources, resourceInfo, targetDate, venueTimezone, filter)
 }
 
-func filterAvailabilityWithResources(resources []api.AvailabilityResource, resourceInfo map[string]api.Resource, startMinutes, endMinutes int, hasTimeRange bool, targetDate, venueTimezone string, showOutdoor, showAll bool) []AvailabilitySlot {
+func filterAvailabilityWithResources(resources []api.AvailabilityResource, resourceInfo map[string]api.Resource, targetDate, venueTimezone string, filter slotFilter) []AvailabilitySlot {
 	slots := []AvailabilitySlot{}
 	for _, resource := range resources {
 		resInfo, hasInfo := resourceInfo[resource.ResourceID]
@@ -253,46 +395,41 @@ func filterAvailabilityWithResources(resources []api.AvailabilityResource, resou
 			isIndoor = resInfo.IsIndoor()
 		}
 
-		// Filter by indoor/outdoor
-		if !showAll {
-			if showOutdoor && isIndoor {
-				continue
-			}
-			if !showOutdoor && !isIndoor {
-				continue
-			}
+		// Filter by indoor/outdoor, size, feature and the venue's court rules
+		if !filter.matchesResource(resInfo, hasInfo) || !filter.allowsCourt(court) {
+			continue
 		}
 
 		for _, slot := range resource.Slots {
+			if !filter.matchesSlot(slot) {
+				continue
+			}
 			resourceDate := resource.StartDate
 			if strings.Contains(resourceDate, "T") && len(resourceDate) >= 10 {
 				resourceDate = resourceDate[:10]
 			}
 			localDate, localTime, startUTC, ok := apiUTCDateTimeToLocal(resourceDate, slot.StartTime, venueTimezone)
-			if ok && targetDate != "" && localDate != targetDate {
-				continue
+			if !ok {
+				localDate = ""
 			}
 			if localTime == "" {
 				localTime = timeLabel(slot.StartTime)
 			}
-			minutes, err := slotMinutes(localTime)
-			if err != nil {
+			minutes, ok := filter.slotStart(localDate, localTime, targetDate)
+			if !ok || !filter.matchesTime(minutes, slot.Duration) {
 				continue
 			}
-			if hasTimeRange {
-				if minutes < startMinutes || minutes > endMinutes {
-					continue
-				}
-			}
 			slots = append(slots, AvailabilitySlot{
 				Court:         court,
-				Time:          localTime,
+				Time:          slotClock(minutes),
 				StartUTC:      startUTC,
 				VenueTimezone: normalizeVenueTimezone(venueTimezone),
 				Duration:      slot.Duration,
 				Available:     true,
 				Price:         slot.Price,
 				Indoor:        isIndoor,
+				Size:          resInfo.Properties.ResourceSize,
+				Feature:       resInfo.Properties.ResourceFeature,
 				ResourceID:    resource.ResourceID,
 			})
 		}
@@ -302,29 +439,98 @@ func filterAvailabilityWithResources(resources []api.AvailabilityResource, resou
 		if slots[i].Court == slots[j].Court {
 			return slots[i].Time < slots[j].Time
 		}
+		if left, right := filter.courtRank(slots[i].Court), filter.courtRank(slots[j].Court); left != right {
+			return left < right
+		}
 		return slots[i].Court < slots[j].Court
 	})
 	return slots
 }
 
-func renderSearch(results []SearchResult) error {
+// searchSpec renders search results. With byPrice the slots are already in
+// price order, and the text view lists them in that order.
+func searchSpec(results []SearchResult, byPrice bool) renderSpec {
+	return renderSpec{
+		Data: results,
+		Records: func() []any {
+			return slotRecordValues(searchRecords(results))
+		},
+		Table: func() tableData {
+			return slotTable(searchRecords(results))
+		},
+		Text: func() error {
+			return renderSearch(results, byPrice)
+		},
+		Chat: func(c chatFormatter) string {
+			return chatSearch(c, results)
+		},
+		Compact: func() error {
+			for _, result := range results {
+				if len(results) > 1 {
+					fmt.Printf("%s\n", result.Date)
+				}
+				fmt.Println(renderCompactSearch(result))
+				if len(results) > 1 {
+					fmt.Println()
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func searchRecords(results []SearchResult) []SlotRecord {
+	records := []SlotRecord{}
 	for _, result := range results {
-		if len(results) > 1 {
-			fmt.Printf("%s\n", result.Date)
+		for _, club := range result.Clubs {
+			for _, slot := range club.Slots {
+				records = append(records, SlotRecord{
+					Date:             result.Date,
+					ClubID:           club.ClubID,
+					ClubName:         club.ClubName,
+					AvailabilitySlot: slot,
+				})
+			}
 		}
+	}
+	return records
+}
 
-		if outputCompact {
-			fmt.Println(renderCompactSearch(result))
-			if len(results) > 1 {
-				fmt.Println()
-			}
-			continue
+func renderSearch(results []SearchResult, byPrice bool) error {
+	for _, result := range results {
+		if len(results) > 1 {
+			fmt.Printf("%s\n", result.Date)
 		}
 
 		for _, club := range result.Clubs {
-			fmt.Printf("%s\n", club.ClubName)
+			details := []string{}
+			if cheapest, ok := cheapestSlot(club.Slots); ok {
+				details = append(details, fmt.Sprintf("from %s, %s pp", formatPriceLabel(cheapest.Price, cheapest.VenueTimezone), formatPerPlayer(cheapest)))
+			}
+			if club.DistanceKm != nil {
+				details = append(details, formatDistance(club.DistanceKm))
+			}
+			if club.TravelMinutes > 0 {
+				details = append(details, formatTravel(club.TravelMinutes))
+			}
+			if len(details) > 0 {
+				fmt.Printf("%s (%s)\n", club.ClubName, strings.Join(details, ", "))
+			} else {
+				fmt.Printf("%s\n", club.ClubName)
+			}
 			if len(club.Slots) == 0 {
-				fmt.Println("  No available slots.")
+				fmt.Printf("  %s\n", noSlotsMessage(club.Reason))
+				continue
+			}
+			if byPrice {
+				for _, slot := range club.Slots {
+					price := ""
+					if slotPriced(slot) {
+						price = fmt.Sprintf("  %s, %s pp", formatPriceLabel(slot.Price, slot.VenueTimezone), formatPerPlayer(slot))
+					}
+					fmt.Printf("  %s  %s%s\n", slot.Time, courtLabel(slot), price)
+				}
+				fmt.Println()
 				continue
 			}
 
@@ -342,7 +548,7 @@ func renderSearch(results []SearchResult) error {
 				for _, slot := range byCourt[court] {
 					times = append(times, slot.Time)
 				}
-				fmt.Printf("  %s: %s\n", court, strings.Join(uniqueSortedTimes(times), "  "))
+				fmt.Printf("  %s: %s\n", courtLabel(byCourt[court][0]), strings.Join(uniqueSortedTimes(times), "  "))
 			}
 			fmt.Println()
 		}
@@ -350,6 +556,19 @@ func renderSearch(results []SearchResult) error {
 	return nil
 }
 
+func courtLabel(slot AvailabilitySlot) string {
+	attributes := []string{}
+	for _, value := range []string{slot.Size, slot.Feature} {
+		if value != "" {
+			attributes = append(attributes, value)
+		}
+	}
+	if len(attributes) == 0 {
+		return slot.Court
+	}
+	return fmt.Sprintf("%s (%s)", slot.Court, strings.Join(attributes, ", "))
+}
+
 func renderCompactSearch(result SearchResult) string {
 	candidateTimes := []string{}
 	for _, club := range result.Clubs {
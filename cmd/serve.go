@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"padel-cli/storage"
+
+	"github.com/spf13/cobra"
+)
+
+func serveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve booking data over HTTP",
+	}
+
+	cmd.AddCommand(serveICSCmd())
+	return cmd
+}
+
+func serveICSCmd() *cobra.Command {
+	var addr string
+	var path string
+	var syncEvery time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "ics",
+		Short: "Serve bookings as a subscribable calendar feed",
+		Long:  "Serve bookings as an iCalendar feed. The feed is built from the bookings database on every request, so syncs, added bookings and cancellations show up the next time the calendar refreshes. With --sync-every the server also syncs from Playtomic itself.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if path == "" || path[0] != '/' {
+				path = "/" + path
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			if syncEvery > 0 {
+				go syncPeriodically(ctx, syncEvery)
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(path, serveBookingsICS)
+			server := &http.Server{
+				Addr:              addr,
+				Handler:           mux,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			go func() {
+				<-ctx.Done()
+				shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = server.Shutdown(shutdown)
+			}()
+
+			fmt.Printf("Serving calendar at http://%s%s\n", addr, path)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8765", "Address to listen on")
+	cmd.Flags().StringVar(&path, "path", "/padel.ics", "URL path of the feed")
+	cmd.Flags().DurationVar(&syncEvery, "sync-every", 0, "Sync from Playtomic at this interval (e.g. 30m; 0 disables)")
+	return cmd
+}
+
+func serveBookingsICS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bookings, err := loadBookings(storage.BookingFilter{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var body bytes.Buffer
+	venueByID, venueByAlias := buildVenueLookups()
+	if err := writeICS(&body, bookings, venueByID, venueByAlias); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body.Bytes())
+}
+
+func syncPeriodically(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		result, err := syncBookings(ctx, time.Time{}, 0, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: sync failed: %v\n", err)
+		} else if result.Added > 0 || result.Cancelled > 0 {
+			fmt.Printf("Synced: added %d, removed %d cancelled.\n", result.Added, result.Cancelled)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
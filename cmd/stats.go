@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"padel-cli/api"
+	"padel-cli/storage"
+)
+
+// StatsBucket is the number of games and the spend for one period, venue or
+// court.
+type StatsBucket struct {
+	Key   string      `json:"key"`
+	Games int         `json:"games"`
+	Hours float64     `json:"hours"`
+	Spent []api.Money `json:"spent"`
+}
+
+// Breakdowns accepted by stats --by.
+const (
+	statsByYear  = "year"
+	statsByMonth = "month"
+	statsByWeek  = "week"
+	statsByVenue = "venue"
+	statsByCourt = "court"
+)
+
+var statsBreakdowns = []string{statsByYear, statsByMonth, statsByWeek, statsByVenue, statsByCourt}
+
+const (
+	sparkTicks = "▁▂▃▄▅▆▇█"
+	barWidth   = 24
+)
+
+// addBreakdowns fills the per period, venue and court breakdowns, the
+// average hourly price, the longest weekly streak and the indoor/outdoor
+// split.
+func addBreakdowns(stats *BookingStats, bookings []storage.Booking, venueByID, venueByAlias map[string]storage.Venue) {
+	years := map[string]*StatsBucket{}
+	months := map[string]*StatsBucket{}
+	weeks := map[string]*StatsBucket{}
+	venues := map[string]*StatsBucket{}
+	courts := map[string]*StatsBucket{}
+	var first, last time.Time
+	var hourly []api.Money
+	hours := map[string]float64{}
+
+	add := func(buckets map[string]*StatsBucket, key string, booking storage.Booking) {
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = &StatsBucket{Key: key}
+			buckets[key] = bucket
+		}
+		bucket.Games++
+		bucket.Hours += float64(booking.Duration) / 60
+		bucket.Spent = sumByCurrency(append(bucket.Spent, bookingMoney(booking)))
+	}
+
+	for _, booking := range bookings {
+		day, err := time.Parse("2006-01-02", booking.Date)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+
+		add(years, day.Format("2006"), booking)
+		add(months, day.Format("2006-01"), booking)
+		add(weeks, isoWeekKey(day), booking)
+		venue := booking.VenueName
+		if venue == "" {
+			venue = booking.VenueAlias
+		}
+		add(venues, venue, booking)
+		if booking.Court != "" {
+			add(courts, venue+" / "+booking.Court, booking)
+		}
+
+		switch bookingCourtType(booking, venueByID, venueByAlias) {
+		case "indoor":
+			stats.Indoor++
+		case "outdoor":
+			stats.Outdoor++
+		default:
+			stats.UnknownCourtType++
+		}
+
+		if booking.Price > 0 && booking.Duration > 0 {
+			money := bookingMoney(booking)
+			hourly = append(hourly, money)
+			hours[money.Currency] += float64(booking.Duration) / 60
+		}
+	}
+
+	for _, total := range sumByCurrency(hourly) {
+		if hours[total.Currency] > 0 {
+			stats.AveragePerHour = append(stats.AveragePerHour, api.Money{
+				Amount:   roundCents(total.Amount / hours[total.Currency]),
+				Currency: total.Currency,
+			})
+		}
+	}
+
+	if !first.IsZero() {
+		stats.ByYear = periodBuckets(years, time.Date(first.Year(), 1, 1, 0, 0, 0, 0, time.UTC), last, func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }, func(t time.Time) string { return t.Format("2006") })
+		stats.ByMonth = periodBuckets(months, time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC), last, func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }, func(t time.Time) string { return t.Format("2006-01") })
+		stats.ByWeek = periodBuckets(weeks, startOfWeek(first), last, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }, isoWeekKey)
+	}
+	stats.ByVenue = rankedBuckets(venues)
+	stats.ByCourt = rankedBuckets(courts)
+	stats.LongestStreakWeeks, stats.LongestStreakStart = longestWeekStreak(stats.ByWeek)
+}
+
+// periodBuckets returns buckets in time order from first to last, including
+// empty periods so that charts show gaps.
+func periodBuckets(buckets map[string]*StatsBucket, first, last time.Time, next func(time.Time) time.Time, key func(time.Time) string) []StatsBucket {
+	result := []StatsBucket{}
+	for current := first; !current.After(last); current = next(current) {
+		label := key(current)
+		if bucket, ok := buckets[label]; ok {
+			result = append(result, *bucket)
+		} else {
+			result = append(result, StatsBucket{Key: label, Spent: []api.Money{}})
+		}
+	}
+	return result
+}
+
+// rankedBuckets orders buckets by games played, then by name.
+func rankedBuckets(buckets map[string]*StatsBucket) []StatsBucket {
+	result := make([]StatsBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, *bucket)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Games != result[j].Games {
+			return result[i].Games > result[j].Games
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func isoWeekKey(day time.Time) string {
+	year, week := day.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// longestWeekStreak returns the longest run of consecutive weeks with at
+// least one game, and the week it started.
+func longestWeekStreak(weeks []StatsBucket) (int, string) {
+	best, bestStart := 0, ""
+	run, runStart := 0, ""
+	for _, week := range weeks {
+		if week.Games == 0 {
+			run = 0
+			continue
+		}
+		if run == 0 {
+			runStart = week.Key
+		}
+		run++
+		if run > best {
+			best, bestStart = run, runStart
+		}
+	}
+	return best, bestStart
+}
+
+// bookingCourtType looks up whether the booked court is indoor or outdoor
+// from the saved venue, or returns "" when that is unknown.
+func bookingCourtType(booking storage.Booking, venueByID, venueByAlias map[string]storage.Venue) string {
+	venue, ok := bookingVenue(booking, venueByID, venueByAlias)
+	if !ok {
+		return ""
+	}
+	for _, court := range venue.Courts {
+		if strings.EqualFold(court.Name, booking.Court) && court.Type != "" {
+			if court.Type == "indoor" {
+				return "indoor"
+			}
+			return "outdoor"
+		}
+	}
+	switch {
+	case venue.IndoorCourts > 0 && venue.OutdoorCourts == 0:
+		return "indoor"
+	case venue.OutdoorCourts > 0 && venue.IndoorCourts == 0:
+		return "outdoor"
+	}
+	return ""
+}
+
+func parseStatsBreakdowns(input string) ([]string, error) {
+	result := []string{}
+	for _, part := range strings.Split(input, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		valid := false
+		for _, name := range statsBreakdowns {
+			if part == name {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown breakdown %q (expected %s)", part, strings.Join(statsBreakdowns, "|"))
+		}
+		result = append(result, part)
+	}
+	return result, nil
+}
+
+func (stats BookingStats) breakdown(name string) []StatsBucket {
+	switch name {
+	case statsByYear:
+		return stats.ByYear
+	case statsByMonth:
+		return stats.ByMonth
+	case statsByWeek:
+		return stats.ByWeek
+	case statsByVenue:
+		return stats.ByVenue
+	case statsByCourt:
+		return stats.ByCourt
+	}
+	return nil
+}
+
+func renderBookingStats(stats BookingStats, breakdowns []string) error {
+	fmt.Printf("Total bookings: %d\n", stats.TotalBookings)
+	fmt.Printf("Total spent: %s\n", formatMoneyList(stats.TotalSpent))
+	if len(stats.AveragePerHour) > 0 {
+		parts := make([]string, 0, len(stats.AveragePerHour))
+		for _, amount := range stats.AveragePerHour {
+			parts = append(parts, formatMoney(amount)+"/h")
+		}
+		fmt.Printf("Average price: %s\n", strings.Join(parts, ", "))
+	}
+	fmt.Printf("Favourite venue: %s (%d bookings)\n", stats.FavouriteVenue, stats.FavouriteVenueCount)
+	fmt.Printf("Usual time: %s\n", stats.UsualTime)
+	fmt.Printf("Last played: %s\n", stats.LastPlayed)
+	if stats.LongestStreakWeeks > 0 {
+		fmt.Printf("Longest streak: %d week%s (from %s)\n", stats.LongestStreakWeeks, plural(stats.LongestStreakWeeks), stats.LongestStreakStart)
+	}
+	courtTypes := fmt.Sprintf("%d indoor, %d outdoor", stats.Indoor, stats.Outdoor)
+	if stats.UnknownCourtType > 0 {
+		courtTypes += fmt.Sprintf(", %d unknown", stats.UnknownCourtType)
+	}
+	fmt.Printf("Courts: %s\n", courtTypes)
+
+	if len(stats.ByWeek) > 1 {
+		values := make([]float64, 0, len(stats.ByWeek))
+		for _, week := range stats.ByWeek {
+			values = append(values, float64(week.Games))
+		}
+		fmt.Printf("\nGames per week (%s to %s)\n%s\n", stats.ByWeek[0].Key, stats.ByWeek[len(stats.ByWeek)-1].Key, sparkline(values))
+	}
+
+	for _, name := range breakdowns {
+		buckets := stats.breakdown(name)
+		if len(buckets) == 0 {
+			continue
+		}
+		fmt.Printf("\nBy %s\n", name)
+		maxGames := 0
+		for _, bucket := range buckets {
+			if bucket.Games > maxGames {
+				maxGames = bucket.Games
+			}
+		}
+		table := tableData{}
+		for _, bucket := range buckets {
+			table.Rows = append(table.Rows, []string{
+				bucket.Key,
+				bar(float64(bucket.Games), float64(maxGames), barWidth),
+				fmt.Sprintf("%d", bucket.Games),
+				statsSpentLabel(bucket.Spent),
+			})
+		}
+		if err := writeTable(os.Stdout, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func statsSpentLabel(spent []api.Money) string {
+	if len(spent) == 0 {
+		return ""
+	}
+	return formatMoneyList(spent)
+}
+
+// sparkline draws values as a row of block characters scaled to the largest
+// value. Zero is drawn as a space so that gaps stand out.
+func sparkline(values []float64) string {
+	ticks := []rune(sparkTicks)
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, value)
+	}
+	var line strings.Builder
+	for _, value := range values {
+		if value <= 0 || maxValue <= 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		index := int(math.Round(value / maxValue * float64(len(ticks)-1)))
+		line.WriteRune(ticks[index])
+	}
+	return line.String()
+}
+
+// bar draws value as a horizontal bar of at most width characters.
+func bar(value, maxValue float64, width int) string {
+	if value <= 0 || maxValue <= 0 {
+		return ""
+	}
+	length := int(math.Round(value / maxValue * float64(width)))
+	if length < 1 {
+		length = 1
+	}
+	return strings.Repeat("█", length)
+}
@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// SuggestConfig holds the standing preferences used by suggest. Flags
+// override each field for a single run.
+type SuggestConfig struct {
+	Venues     []string           `json:"venues"`
+	IdealTime  string             `json:"ideal_time"`
+	Time       string             `json:"time"`
+	Days       []string           `json:"days"`
+	Feature    string             `json:"feature"`
+	IndoorOnly bool               `json:"indoor_only"`
+	Weights    map[string]float64 `json:"weights"`
+}
+
+// Suggestion is one ranked slot with the points each preference gave it.
+type Suggestion struct {
+	Rank       int                `json:"rank"`
+	Score      float64            `json:"score"`
+	Date       string             `json:"date"`
+	ClubID     string             `json:"club_id"`
+	ClubName   string             `json:"club_name"`
+	VenueAlias string             `json:"venue_alias,omitempty"`
+	Slot       AvailabilitySlot   `json:"slot"`
+	Breakdown  map[string]float64 `json:"breakdown"`
+	Reasons    []string           `json:"reasons"`
+}
+
+const (
+	weightVenue   = "venue"
+	weightTime    = "time"
+	weightPrice   = "price"
+	weightIndoor  = "indoor"
+	weightFeature = "feature"
+	weightDay     = "day"
+
+	// idealTimeFalloff is how many minutes away from the ideal time a slot
+	// may start before it gets no time points at all.
+	idealTimeFalloff = 120
+)
+
+var defaultSuggestWeights = map[string]float64{
+	weightVenue:   3,
+	weightTime:    3,
+	weightPrice:   1,
+	weightIndoor:  2,
+	weightFeature: 0.5,
+	weightDay:     1,
+}
+
+type suggestPrefs struct {
+	Venues  []string
+	Ideal   []timeWindow
+	Days    map[time.Weekday]bool
+	Feature string
+	Weights map[string]float64
+}
+
+func suggestCmd() *cobra.Command {
+	var venuesInput string
+	var date string
+	var top int
+	var ideal string
+	var days string
+	var feature string
+	var indoorOnly bool
+	var weights []string
+	var filter slotFilter
+	var players int
+
+	cmd := &cobra.Command{
+		Use:   "suggest",
+		Short: "Rank available slots across venues by your preferences",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if date == "" {
+				return fmt.Errorf("--date is required")
+			}
+			if top <= 0 {
+				return fmt.Errorf("--top must be positive")
+			}
+
+			aliases := splitAliases(venuesInput)
+			if len(aliases) == 0 {
+				aliases = cfg.Suggest.Venues
+			}
+			if len(aliases) == 0 {
+				return fmt.Errorf("--venues is required (or set suggest.venues in config)")
+			}
+
+			if !cmd.Flags().Changed("time") {
+				filter.TimeRange = cfg.Suggest.Time
+			}
+			if !cmd.Flags().Changed("duration") && cfg.PreferredDuration > 0 {
+				filter.Duration = cfg.PreferredDuration
+			}
+			if !cmd.Flags().Changed("indoor-only") {
+				indoorOnly = cfg.Suggest.IndoorOnly
+			}
+			filter.ShowAll = !indoorOnly
+			if err := filter.validate(); err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Changed("ideal") {
+				ideal = cfg.Suggest.IdealTime
+			}
+			if !cmd.Flags().Changed("prefer-days") {
+				days = strings.Join(cfg.Suggest.Days, ",")
+			}
+			if !cmd.Flags().Changed("prefer-feature") {
+				feature = cfg.Suggest.Feature
+			}
+			prefs, err := newSuggestPrefs(aliases, ideal, days, feature, cfg.Suggest.Weights, weights)
+			if err != nil {
+				return err
+			}
+
+			if _, err := parseDateRangeInLocation(date, time.Local); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			tenants, err := loadVenueTenants(ctx, aliases)
+			if err != nil {
+				return err
+			}
+			// Rank by the resolved venues so "@group" entries count in
+			// their group's order.
+			prefs.Venues = nil
+			for _, tenant := range tenants {
+				prefs.Venues = append(prefs.Venues, tenant.Alias)
+			}
+			dayList, err := parseDateRangeInLocation(date, venueLocation(tenants[0].TimeZone))
+			if err != nil {
+				return err
+			}
+
+			results, err := fetchSearchResults(ctx, tenants, formatDays(dayList), filter, players)
+			if err != nil {
+				return err
+			}
+
+			suggestions := scoreSlots(results, tenants, prefs)
+			if len(suggestions) > top {
+				suggestions = suggestions[:top]
+			}
+			return render(suggestSpec(suggestions))
+		},
+	}
+
+	cmd.Flags().StringVar(&venuesInput, "venues", "", "Comma-separated venue aliases or @group, most preferred first (default suggest.venues)")
+	cmd.Flags().StringVar(&date, "date", "", "Date or date expression (sat, weekend, this week, A..B)")
+	cmd.Flags().IntVar(&top, "top", 5, "Number of suggestions to show")
+	cmd.Flags().StringVar(&ideal, "ideal", "", "Ideal start time windows (e.g. 10:00-10:30)")
+	cmd.Flags().StringVar(&days, "prefer-days", "", "Preferred weekdays (e.g. sat,sun)")
+	cmd.Flags().StringVar(&feature, "prefer-feature", "", "Preferred court feature (e.g. panoramic)")
+	cmd.Flags().BoolVar(&indoorOnly, "indoor-only", false, "Only consider indoor courts")
+	cmd.Flags().StringSliceVar(&weights, "weight", nil, "Override a weight, e.g. --weight price=2 (venue|time|price|indoor|feature|day)")
+	addTimeWindowFlags(cmd, &filter)
+	addSlotFilterFlags(cmd, &filter)
+	cmd.Flags().IntVar(&players, "players", defaultPlayers, "Number of players to split the price between")
+	return cmd
+}
+
+func newSuggestPrefs(venues []string, ideal, days, feature string, configured map[string]float64, overrides []string) (suggestPrefs, error) {
+	prefs := suggestPrefs{
+		Venues:  venues,
+		Days:    map[time.Weekday]bool{},
+		Feature: strings.ToLower(strings.TrimSpace(feature)),
+		Weights: map[string]float64{},
+	}
+	for name, weight := range defaultSuggestWeights {
+		prefs.Weights[name] = weight
+	}
+	for name, weight := range configured {
+		if err := setSuggestWeight(prefs.Weights, name, weight); err != nil {
+			return suggestPrefs{}, err
+		}
+	}
+	for _, override := range overrides {
+		name, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return suggestPrefs{}, fmt.Errorf("invalid --weight %q (expected name=value)", override)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return suggestPrefs{}, fmt.Errorf("invalid --weight %q: %w", override, err)
+		}
+		if err := setSuggestWeight(prefs.Weights, name, weight); err != nil {
+			return suggestPrefs{}, err
+		}
+	}
+
+	if strings.TrimSpace(ideal) != "" {
+		windows, err := parseTimeWindows(ideal)
+		if err != nil {
+			return suggestPrefs{}, err
+		}
+		prefs.Ideal = windows
+	}
+	for _, name := range splitAliases(strings.ToLower(days)) {
+		weekday, ok := weekdayNames[name]
+		if !ok {
+			return suggestPrefs{}, fmt.Errorf("unknown weekday %q", name)
+		}
+		prefs.Days[weekday] = true
+	}
+	return prefs, nil
+}
+
+func setSuggestWeight(weights map[string]float64, name string, weight float64) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := defaultSuggestWeights[name]; !ok {
+		return fmt.Errorf("unknown weight %q (expected venue|time|price|indoor|feature|day)", name)
+	}
+	if weight < 0 {
+		return fmt.Errorf("weight %q must not be negative", name)
+	}
+	weights[name] = weight
+	return nil
+}
+
+// scoreSlots rates every slot from 0 to 1 on each preference, multiplies by
+// the preference's weight and ranks slots by the total. Preferences that are
+// not set (no ideal time, no preferred days or feature) score nothing.
+func scoreSlots(results []SearchResult, tenants []searchTenant, prefs suggestPrefs) []Suggestion {
+	aliases := map[string]string{}
+	for _, tenant := range tenants {
+		aliases[tenant.Tenant.TenantID] = tenant.Alias
+	}
+	venueRank := map[string]int{}
+	for idx, alias := range prefs.Venues {
+		venueRank[strings.ToLower(alias)] = idx
+	}
+
+	// Prices are only comparable within a currency, so each currency is
+	// scored against its own cheapest and dearest slot.
+	type priceRange struct{ min, max float64 }
+	priceRanges := map[string]priceRange{}
+	for _, result := range results {
+		for _, club := range result.Clubs {
+			for _, slot := range club.Slots {
+				if slot.PricePerHour <= 0 {
+					continue
+				}
+				span, ok := priceRanges[slot.Currency]
+				if !ok {
+					span = priceRange{min: math.Inf(1), max: math.Inf(-1)}
+				}
+				span.min = math.Min(span.min, slot.PricePerHour)
+				span.max = math.Max(span.max, slot.PricePerHour)
+				priceRanges[slot.Currency] = span
+			}
+		}
+	}
+
+	suggestions := []Suggestion{}
+	for _, result := range results {
+		day, _ := time.Parse("2006-01-02", result.Date)
+		for _, club := range result.Clubs {
+			alias := aliases[club.ClubID]
+			for _, slot := range club.Slots {
+				suggestion := Suggestion{
+					Date:       result.Date,
+					ClubID:     club.ClubID,
+					ClubName:   club.ClubName,
+					VenueAlias: alias,
+					Slot:       slot,
+					Reasons:    []string{},
+					Breakdown:  map[string]float64{},
+				}
+				add := func(name string, score float64, reason string) {
+					points := roundCents(score * prefs.Weights[name])
+					suggestion.Breakdown[name] = points
+					suggestion.Score += points
+					// Only explain what earned points.
+					if reason != "" && points > 0 {
+						suggestion.Reasons = append(suggestion.Reasons, reason)
+					}
+				}
+
+				if rank, ok := venueRank[strings.ToLower(alias)]; ok {
+					add(weightVenue, float64(len(prefs.Venues)-rank)/float64(len(prefs.Venues)), ordinal(rank+1)+" choice venue")
+				} else {
+					add(weightVenue, 0, "")
+				}
+
+				if len(prefs.Ideal) > 0 {
+					if minutes, err := slotMinutes(slot.Time); err == nil {
+						distance := idealTimeDistance(prefs.Ideal, minutes)
+						score := math.Max(0, 1-float64(distance)/idealTimeFalloff)
+						reason := "ideal time"
+						if distance > 0 {
+							reason = fmt.Sprintf("%d min from ideal time", distance)
+						}
+						add(weightTime, score, reason)
+					}
+				}
+
+				if slot.PricePerHour > 0 {
+					span := priceRanges[slot.Currency]
+					score := 1.0
+					if span.max > span.min {
+						score = (span.max - slot.PricePerHour) / (span.max - span.min)
+					}
+					reason := formatPerHour(slot) + "/h"
+					if slot.PricePerHour == span.min {
+						reason = "cheapest (" + reason + ")"
+					}
+					add(weightPrice, score, reason)
+				}
+
+				if slot.Indoor {
+					add(weightIndoor, 1, "indoor")
+				} else {
+					add(weightIndoor, 0, "")
+				}
+
+				if prefs.Feature != "" {
+					if strings.EqualFold(slot.Feature, prefs.Feature) {
+						add(weightFeature, 1, slot.Feature)
+					} else {
+						add(weightFeature, 0, "")
+					}
+				}
+
+				if len(prefs.Days) > 0 && !day.IsZero() {
+					if prefs.Days[day.Weekday()] {
+						add(weightDay, 1, "preferred day ("+day.Format("Mon")+")")
+					} else {
+						add(weightDay, 0, "")
+					}
+				}
+
+				suggestion.Score = roundCents(suggestion.Score)
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		left, right := suggestions[i], suggestions[j]
+		if left.Score != right.Score {
+			return left.Score > right.Score
+		}
+		if left.Date != right.Date {
+			return left.Date < right.Date
+		}
+		if left.Slot.Time != right.Slot.Time {
+			return left.Slot.Time < right.Slot.Time
+		}
+		return left.Slot.Court < right.Slot.Court
+	})
+	for idx := range suggestions {
+		suggestions[idx].Rank = idx + 1
+	}
+	return suggestions
+}
+
+// idealTimeDistance returns how many minutes a start time lies outside the
+// nearest ideal window, or 0 when it is inside one.
+func idealTimeDistance(windows []timeWindow, minutes int) int {
+	best := -1
+	for _, window := range windows {
+		for _, offset := range []int{0, minutesPerDay} {
+			start := minutes + offset
+			distance := 0
+			switch {
+			case start < window.Start:
+				distance = window.Start - start
+			case start > window.End:
+				distance = start - window.End
+			}
+			if best < 0 || distance < best {
+				best = distance
+			}
+		}
+	}
+	return best
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+func suggestSpec(suggestions []Suggestion) renderSpec {
+	return renderSpec{
+		Data: suggestions,
+		Table: func() tableData {
+			return suggestTable(suggestions)
+		},
+		Text: func() error {
+			return renderSuggestions(suggestions)
+		},
+		Chat: func(c chatFormatter) string {
+			return chatSuggestions(c, suggestions)
+		},
+		Empty: "No slots match.",
+	}
+}
+
+func suggestTable(suggestions []Suggestion) tableData {
+	table := tableData{Headers: []string{"RANK", "SCORE", "DATE", "TIME", "CLUB", "COURT", "DURATION", "PRICE", "WHY"}}
+	for _, suggestion := range suggestions {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(suggestion.Rank),
+			strconv.FormatFloat(suggestion.Score, 'f', 2, 64),
+			suggestion.Date,
+			suggestion.Slot.Time,
+			suggestion.ClubName,
+			suggestion.Slot.Court,
+			fmt.Sprintf("%d", suggestion.Slot.Duration),
+			suggestion.Slot.Price,
+			strings.Join(suggestion.Reasons, ", "),
+		})
+	}
+	return table
+}
+
+func renderSuggestions(suggestions []Suggestion) error {
+	if len(suggestions) == 0 {
+		fmt.Println("No slots match.")
+		return nil
+	}
+	for _, suggestion := range suggestions {
+		fmt.Fprintf(os.Stdout, "%d. %s %s @ %s - %s (%dm, %s)  score %.2f\n",
+			suggestion.Rank,
+			chatDayLabel(suggestion.Date),
+			suggestion.Slot.Time,
+			suggestion.ClubName,
+			courtLabel(suggestion.Slot),
+			suggestion.Slot.Duration,
+			formatPriceLabel(suggestion.Slot.Price, suggestion.Slot.VenueTimezone),
+			suggestion.Score,
+		)
+		if len(suggestion.Reasons) > 0 {
+			fmt.Printf("   %s\n", strings.Join(suggestion.Reasons, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScoreSlotsPricesPerCurrency(t *testing.T) {
+	results := []SearchResult{{
+		Date: "2026-10-24",
+		Clubs: []SearchClubResult{
+			{ClubID: "nl", ClubName: "Dutch club", Slots: []AvailabilitySlot{
+				{Court: "eur-cheap", Time: "10:00", Duration: 60, Indoor: true, PricePerHour: 30, Currency: "EUR"},
+				{Court: "eur-dear", Time: "11:00", Duration: 60, Indoor: false, PricePerHour: 40, Currency: "EUR"},
+			}},
+			{ClubID: "se", ClubName: "Swedish club", Slots: []AvailabilitySlot{
+				{Court: "sek-cheap", Time: "10:00", Duration: 60, Indoor: true, PricePerHour: 350, Currency: "SEK"},
+				{Court: "sek-dear", Time: "11:00", Duration: 60, Indoor: true, PricePerHour: 450, Currency: "SEK"},
+			}},
+		},
+	}}
+	prefs := suggestPrefs{Weights: defaultSuggestWeights}
+
+	byCourt := map[string]Suggestion{}
+	for _, suggestion := range scoreSlots(results, nil, prefs) {
+		byCourt[suggestion.Slot.Court] = suggestion
+	}
+
+	// The cheapest slot of each currency gets the full price score, however
+	// the amounts compare across currencies.
+	for court, want := range map[string]float64{"eur-cheap": 1, "eur-dear": 0, "sek-cheap": 1, "sek-dear": 0} {
+		if got := byCourt[court].Breakdown[weightPrice]; got != want*defaultSuggestWeights[weightPrice] {
+			t.Errorf("%s price points = %v, want %v", court, got, want*defaultSuggestWeights[weightPrice])
+		}
+	}
+
+	// Reasons only list what earned points.
+	dear := byCourt["eur-dear"].Reasons
+	if len(dear) != 0 {
+		t.Errorf("eur-dear reasons = %q, want none", dear)
+	}
+	if got := byCourt["sek-cheap"].Reasons; !slices.Contains(got, "indoor") || len(got) != 2 {
+		t.Errorf("sek-cheap reasons = %q, want cheapest price and indoor", got)
+	}
+}
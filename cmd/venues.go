@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"path"
 	"sort"
+	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
+	"padel-cli/api"
 	"padel-cli/storage"
 
 	"github.com/spf13/cobra"
@@ -20,6 +25,11 @@ func venuesCmd() *cobra.Command {
 
 	cmd.AddCommand(venuesListCmd())
 	cmd.AddCommand(venuesAddCmd())
+	cmd.AddCommand(venuesDiscoverCmd())
+	cmd.AddCommand(venuesShowCmd())
+	cmd.AddCommand(venuesRefreshCmd())
+	cmd.AddCommand(venuesGroupCmd())
+	cmd.AddCommand(venuesRulesCmd())
 	cmd.AddCommand(venuesRemoveCmd())
 	return cmd
 }
@@ -38,35 +48,165 @@ func venuesListCmd() *cobra.Command {
 				return strings.ToLower(venues[i].Alias) < strings.ToLower(venues[j].Alias)
 			})
 
-			if outputJSON {
-				return writeJSON(venues)
+			return render(renderSpec{
+				Data:  venues,
+				Empty: "No venues saved.",
+				Table: func() tableData {
+					table := tableData{Headers: []string{"ALIAS", "NAME", "COURTS", "TIMEZONE", "REFRESHED", "STATUS"}}
+					for _, venue := range venues {
+						table.Rows = append(table.Rows, []string{venue.Alias, venue.Name, venueCourtsLabel(venue), venue.TimeZone, refreshedLabel(venue), venueStatus(venue)})
+					}
+					return table
+				},
+			})
+		},
+	}
+
+	return cmd
+}
+
+func venuesShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <alias>",
+		Short: "Show the details of a saved venue",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			venue, err := lookupVenue(args[0])
+			if err != nil {
+				return err
 			}
 
-			if len(venues) == 0 {
-				fmt.Println("No venues saved.")
-				return nil
+			courtsTable := func() tableData {
+				table := tableData{Headers: []string{"COURT", "TYPE", "SIZE", "FEATURE", "ID"}}
+				for _, court := range venue.Courts {
+					table.Rows = append(table.Rows, []string{court.Name, court.Type, court.Size, court.Feature, court.ID})
+				}
+				return table
 			}
+			return render(renderSpec{
+				Data:  venue,
+				Table: courtsTable,
+				Text: func() error {
+					fmt.Printf("%s (%s)\n", venue.Name, venue.Alias)
+					fmt.Printf("ID: %s\n", venue.ID)
+					if venue.Address != "" {
+						fmt.Printf("Address: %s\n", venue.Address)
+					}
+					if venue.Lat != 0 || venue.Lon != 0 {
+						fmt.Printf("Coordinates: %.6f,%.6f\n", venue.Lat, venue.Lon)
+					}
+					fmt.Printf("Timezone: %s\n", venue.TimeZone)
+					fmt.Printf("Courts: %s\n", venueCourtsLabel(venue))
+					fmt.Printf("Refreshed: %s\n", refreshedLabel(venue))
+					if status := venueStatus(venue); status != "" {
+						fmt.Printf("Status: %s\n", status)
+					}
+					if rules := venue.CourtRules; rules != nil {
+						printCourtRules(*rules)
+					}
+					if policy := venue.Policy; policy != nil {
+						if len(policy.OpeningHours) > 0 {
+							fmt.Printf("Opening hours: %s\n", strings.Join(formatOpeningHours(*policy), ", "))
+						}
+						if policy.MaxAdvanceDays > 0 {
+							fmt.Printf("Bookable: %d days ahead\n", policy.MaxAdvanceDays)
+						}
+						if policy.CancellationWindowMinutes > 0 {
+							fmt.Printf("Free cancellation: up to %s before\n", formatTravel(policy.CancellationWindowMinutes))
+						}
+					}
+					if len(venue.Courts) == 0 {
+						return nil
+					}
+					fmt.Println()
+					return writeTable(os.Stdout, courtsTable())
+				},
+			})
+		},
+	}
+
+	return cmd
+}
 
-			writer := tabwriter.NewWriter(os.Stdout, 2, 2, 2, ' ', 0)
-			if !outputCompact {
-				fmt.Fprintln(writer, "ALIAS\tNAME\tINDOOR")
+func venuesRefreshCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refresh [alias...]",
+		Short: "Re-fetch address, location and courts for saved venues",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			venues, err := storage.LoadVenues()
+			if err != nil {
+				return err
 			}
-			for _, venue := range venues {
-				indoor := "no"
-				if venue.Indoor {
-					indoor = "yes"
+
+			selected := map[string]bool{}
+			for _, alias := range args {
+				if _, ok := storage.FindVenueByAlias(venues, alias); !ok {
+					return fmt.Errorf("venue alias %q not found", alias)
+				}
+				selected[strings.ToLower(alias)] = true
+			}
+
+			ctx := context.Background()
+			failed := 0
+			for idx := range venues {
+				venue := &venues[idx]
+				if len(selected) > 0 && !selected[strings.ToLower(venue.Alias)] {
+					continue
+				}
+				if err := refreshVenue(ctx, venue); err != nil {
+					failed++
+					fmt.Fprintf(os.Stderr, "%s: %v\n", venue.Alias, err)
+					continue
 				}
-				fmt.Fprintf(writer, "%s\t%s\t%s\n", venue.Alias, venue.Name, indoor)
+				if venue.NotFound {
+					fmt.Printf("%s: tenant %s no longer exists\n", venue.Alias, venue.ID)
+				} else {
+					fmt.Printf("%s: %s, %s\n", venue.Alias, venue.Name, venueCourtsLabel(*venue))
+				}
+				time.Sleep(rateLimitDelay)
+			}
+
+			if err := storage.SaveVenues(venues); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d venue(s) could not be refreshed", failed)
 			}
-			return writer.Flush()
+			return nil
 		},
 	}
 
 	return cmd
 }
 
+func venueCourtsLabel(venue storage.Venue) string {
+	if venue.IndoorCourts == 0 && venue.OutdoorCourts == 0 {
+		if venue.Indoor {
+			return "indoor"
+		}
+		return ""
+	}
+	return fmt.Sprintf("%d indoor, %d outdoor", venue.IndoorCourts, venue.OutdoorCourts)
+}
+
+func refreshedLabel(venue storage.Venue) string {
+	refreshed, err := time.Parse(time.RFC3339, venue.RefreshedAt)
+	if err != nil {
+		return "never"
+	}
+	return refreshed.Local().Format("2006-01-02 15:04")
+}
+
+func venueStatus(venue storage.Venue) string {
+	if venue.NotFound {
+		return "NOT FOUND"
+	}
+	return ""
+}
+
 func venuesAddCmd() *cobra.Command {
 	var id string
+	var fromClub string
 	var alias string
 	var name string
 	var indoor bool
@@ -77,11 +217,14 @@ func venuesAddCmd() *cobra.Command {
 		Short: "Add a saved venue",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			alias = strings.TrimSpace(alias)
-			if id == "" || alias == "" || name == "" {
-				return fmt.Errorf("--id, --alias, and --name are required")
+			if id != "" && fromClub != "" {
+				return fmt.Errorf("use either --id or --from-club, not both")
 			}
-			if timezone == "" {
-				timezone = storage.DefaultVenueTimezone
+			if fromClub == "" && (id == "" || alias == "" || name == "") {
+				return fmt.Errorf("--id, --alias, and --name are required (or use --from-club)")
+			}
+			if alias == "" {
+				return fmt.Errorf("--alias is required")
 			}
 
 			venues, err := storage.LoadVenues()
@@ -93,24 +236,44 @@ func venuesAddCmd() *cobra.Command {
 				return fmt.Errorf("venue alias %q already exists", alias)
 			}
 
-			venues = append(venues, storage.Venue{
-				ID:     id,
-				Alias:  alias,
-				Name:   name,
-				Indoor: indoor,
+			venue := storage.Venue{
+				ID:       id,
+				Alias:    alias,
+				Name:     name,
+				Indoor:   indoor,
 				TimeZone: timezone,
-			})
+			}
+			if fromClub != "" {
+				venue, err = venueFromClub(context.Background(), fromClub, alias)
+				if err != nil {
+					return err
+				}
+				if name != "" {
+					venue.Name = name
+				}
+				if cmd.Flags().Changed("timezone") {
+					venue.TimeZone = timezone
+				}
+				if cmd.Flags().Changed("indoor") {
+					venue.Indoor = indoor
+				}
+			}
+			if venue.TimeZone == "" {
+				venue.TimeZone = storage.DefaultVenueTimezone
+			}
 
+			venues = append(venues, venue)
 			if err := storage.SaveVenues(venues); err != nil {
 				return err
 			}
 
-			fmt.Printf("Saved venue %s (%s).\n", alias, name)
+			fmt.Printf("Saved venue %s (%s).\n", alias, venue.Name)
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&id, "id", "", "Venue (tenant) ID")
+	cmd.Flags().StringVar(&fromClub, "from-club", "", "Tenant ID to fetch the name, timezone, location and courts from")
 	cmd.Flags().StringVar(&alias, "alias", "", "Short alias")
 	cmd.Flags().StringVar(&name, "name", "", "Venue name")
 	cmd.Flags().BoolVar(&indoor, "indoor", false, "Indoor venue")
@@ -118,6 +281,271 @@ func venuesAddCmd() *cobra.Command {
 	return cmd
 }
 
+func venuesDiscoverCmd() *cobra.Command {
+	var near string
+	var radius int
+	var maxDistance float64
+
+	cmd := &cobra.Command{
+		Use:   "discover",
+		Short: "Pick clubs near a location and save them as venues",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if near == "" {
+				near = cfg.DefaultLocation
+			}
+			if near == "" {
+				return fmt.Errorf("--near is required (or set default_location in config)")
+			}
+
+			ctx := context.Background()
+			lat, lon, err := resolveLocation(ctx, near)
+			if err != nil {
+				return err
+			}
+			meter, err := newDistanceMeter(lat, lon)
+			if err != nil {
+				return err
+			}
+			tenants, err := client.GetTenants(ctx, lat, lon, radius)
+			if err != nil {
+				return err
+			}
+
+			type candidate struct {
+				tenant   api.Tenant
+				distance *float64
+			}
+			candidates := []candidate{}
+			for _, tenant := range tenants {
+				distance := meter.distanceKm(tenant.Address.Coord)
+				if !withinDistance(distance, maxDistance) {
+					continue
+				}
+				candidates = append(candidates, candidate{tenant: tenant, distance: distance})
+			}
+			sort.Slice(candidates, func(i, j int) bool {
+				if order := compareDistance(candidates[i].distance, candidates[j].distance); order != 0 {
+					return order < 0
+				}
+				return candidates[i].tenant.TenantName < candidates[j].tenant.TenantName
+			})
+			if len(candidates) == 0 {
+				fmt.Println("No clubs found.")
+				return nil
+			}
+
+			venues, err := storage.LoadVenues()
+			if err != nil {
+				return err
+			}
+			saved := map[string]string{}
+			for _, venue := range venues {
+				saved[venue.ID] = venue.Alias
+			}
+
+			table := tableData{Headers: []string{"#", "NAME", "DISTANCE", "ADDRESS", "SAVED AS"}}
+			for idx, candidate := range candidates {
+				table.Rows = append(table.Rows, []string{
+					strconv.Itoa(idx + 1),
+					candidate.tenant.TenantName,
+					formatDistance(candidate.distance),
+					formatAddress(candidate.tenant.Address),
+					saved[candidate.tenant.TenantID],
+				})
+			}
+			if err := writeTable(os.Stdout, table); err != nil {
+				return err
+			}
+
+			reader := bufio.NewReader(os.Stdin)
+			fmt.Print("Add which clubs? (e.g. 1,3; empty to cancel): ")
+			answer, err := reader.ReadString('\n')
+			if err != nil && answer == "" {
+				return err
+			}
+			picks, err := parsePicks(answer, len(candidates))
+			if err != nil {
+				return err
+			}
+
+			added := 0
+			for _, pick := range picks {
+				tenant := candidates[pick].tenant
+				if alias, ok := saved[tenant.TenantID]; ok {
+					fmt.Printf("%s is already saved as %s.\n", tenant.TenantName, alias)
+					continue
+				}
+				suggested := suggestAlias(tenant.TenantName, venues)
+				fmt.Printf("Alias for %s [%s]: ", tenant.TenantName, suggested)
+				value, err := reader.ReadString('\n')
+				if err != nil && value == "" {
+					return err
+				}
+				alias := strings.TrimSpace(value)
+				if alias == "" {
+					alias = suggested
+				}
+				if _, ok := storage.FindVenueByAlias(venues, alias); ok {
+					return fmt.Errorf("venue alias %q already exists", alias)
+				}
+
+				venue, err := venueFromClub(ctx, tenant.TenantID, alias)
+				if err != nil {
+					return err
+				}
+				venues = append(venues, venue)
+				saved[venue.ID] = venue.Alias
+				added++
+				fmt.Printf("Saved venue %s (%s, %d indoor / %d outdoor courts).\n", venue.Alias, venue.Name, venue.IndoorCourts, venue.OutdoorCourts)
+				time.Sleep(rateLimitDelay)
+			}
+
+			if added == 0 {
+				return nil
+			}
+			return storage.SaveVenues(venues)
+		},
+	}
+
+	cmd.Flags().StringVar(&near, "near", "", "Saved location name, place name or lat,lon")
+	cmd.Flags().IntVar(&radius, "radius", 20000, "Search radius in meters")
+	cmd.Flags().Float64Var(&maxDistance, "max-distance", 0, "Only list clubs within this many km")
+	return cmd
+}
+
+// venueFromClub builds a saved venue from the tenant's own details and its
+// court list.
+func venueFromClub(ctx context.Context, tenantID, alias string) (storage.Venue, error) {
+	venue := storage.Venue{ID: tenantID, Alias: alias}
+	if err := refreshVenue(ctx, &venue); err != nil {
+		return storage.Venue{}, err
+	}
+	if venue.NotFound {
+		return storage.Venue{}, fmt.Errorf("club %q not found", tenantID)
+	}
+	return venue, nil
+}
+
+// refreshVenue re-fetches the tenant behind a venue and updates its name (if
+// unset), timezone, address, coordinates and courts. A tenant that no longer
+// exists marks the venue as not found instead of failing.
+func refreshVenue(ctx context.Context, venue *storage.Venue) error {
+	tenant, err := client.GetTenant(ctx, venue.ID)
+	if err != nil {
+		if api.IsNotFound(err) {
+			venue.NotFound = true
+			return nil
+		}
+		return err
+	}
+	resources, err := client.GetResources(ctx, venue.ID)
+	if err != nil {
+		// Fall back to tenant resources if GetResources fails
+		resources = tenant.Resources
+	}
+
+	if venue.Name == "" {
+		venue.Name = tenant.TenantName
+	}
+	if tenant.Address.TimeZone != "" {
+		venue.TimeZone = normalizeVenueTimezone(tenant.Address.TimeZone)
+	}
+	venue.Address = formatAddress(tenant.Address)
+	venue.Lat = tenant.Address.Coord.Lat
+	venue.Lon = tenant.Address.Coord.Lon
+	venue.IndoorCourts = 0
+	venue.OutdoorCourts = 0
+	venue.Courts = nil
+	for _, resource := range resources {
+		if resource.IsIndoor() {
+			venue.IndoorCourts++
+		} else {
+			venue.OutdoorCourts++
+		}
+		venue.Courts = append(venue.Courts, storage.VenueCourt{
+			ID:      resource.ResourceID,
+			Name:    resource.Name,
+			Type:    resource.Properties.ResourceType,
+			Size:    resource.Properties.ResourceSize,
+			Feature: resource.Properties.ResourceFeature,
+		})
+	}
+	sort.Slice(venue.Courts, func(i, j int) bool {
+		return venue.Courts[i].Name < venue.Courts[j].Name
+	})
+	venue.Indoor = venue.IndoorCourts > 0
+	if policy := tenantPolicy(tenant); policy != nil {
+		venue.Policy = policy
+	}
+	venue.NotFound = false
+	venue.RefreshedAt = time.Now().UTC().Format(time.RFC3339)
+	return nil
+}
+
+// parsePicks parses a 1-based selection such as "1,3" or "2-4" into indexes.
+func parsePicks(input string, count int) ([]int, error) {
+	picks := []int{}
+	seen := map[int]bool{}
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last := part, part
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			first, last = strings.TrimSpace(from), strings.TrimSpace(to)
+		}
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		end, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		if start < 1 || end > count || start > end {
+			return nil, fmt.Errorf("selection %q is out of range (1-%d)", part, count)
+		}
+		for n := start; n <= end; n++ {
+			if !seen[n] {
+				seen[n] = true
+				picks = append(picks, n-1)
+			}
+		}
+	}
+	return picks, nil
+}
+
+// suggestAlias proposes a short, unused alias from a club name, e.g.
+// "Padel Club Blijdorp" becomes "blijdorp".
+func suggestAlias(name string, venues []storage.Venue) string {
+	generic := map[string]bool{"padel": true, "club": true, "sports": true, "sport": true, "the": true, "de": true, "het": true, "and": true, "center": true, "centre": true, "tennis": true}
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	base := ""
+	for _, word := range words {
+		if !generic[word] {
+			base = word
+			break
+		}
+	}
+	if base == "" && len(words) > 0 {
+		base = strings.Join(words, "")
+	}
+	if base == "" {
+		base = "venue"
+	}
+
+	alias := base
+	for n := 2; ; n++ {
+		if _, ok := storage.FindVenueByAlias(venues, alias); !ok {
+			return alias
+		}
+		alias = fmt.Sprintf("%s%d", base, n)
+	}
+}
+
 func venuesRemoveCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "remove <alias>",
@@ -147,6 +575,29 @@ func venuesRemoveCmd() *cobra.Command {
 				return err
 			}
 
+			groups, err := storage.LoadVenueGroups()
+			if err != nil {
+				return err
+			}
+			for name, members := range groups {
+				kept := members[:0]
+				for _, member := range members {
+					if !strings.EqualFold(member, alias) {
+						kept = append(kept, member)
+					}
+				}
+				if len(kept) == 0 {
+					// An empty group would resolve to no venues at all.
+					delete(groups, name)
+					fmt.Printf("Removed group @%s, which has no venues left.\n", name)
+					continue
+				}
+				groups[name] = kept
+			}
+			if err := storage.SaveVenueGroups(groups); err != nil {
+				return err
+			}
+
 			fmt.Printf("Removed venue %s.\n", alias)
 			return nil
 		},
@@ -154,3 +605,215 @@ func venuesRemoveCmd() *cobra.Command {
 
 	return cmd
 }
+
+func venuesGroupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage venue groups (use them as --venues @name)",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List venue groups",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups, err := storage.LoadVenueGroups()
+			if err != nil {
+				return err
+			}
+			names := make([]string, 0, len(groups))
+			for name := range groups {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			return render(renderSpec{
+				Data:  groups,
+				Empty: "No venue groups saved.",
+				Table: func() tableData {
+					table := tableData{Headers: []string{"GROUP", "VENUES"}}
+					for _, name := range names {
+						table.Rows = append(table.Rows, []string{"@" + name, strings.Join(groups[name], ", ")})
+					}
+					return table
+				},
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <name> <alias>...",
+		Short: "Create or replace a group; aliases are listed most preferred first",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimPrefix(strings.TrimSpace(args[0]), "@")
+			if name == "" || strings.ContainsAny(name, ", ") {
+				return fmt.Errorf("invalid group name %q", args[0])
+			}
+			venues, err := storage.LoadVenues()
+			if err != nil {
+				return err
+			}
+			aliases := []string{}
+			for _, arg := range args[1:] {
+				for _, alias := range splitAliases(arg) {
+					venue, ok := storage.FindVenueByAlias(venues, alias)
+					if !ok {
+						return fmt.Errorf("venue alias %q not found", alias)
+					}
+					aliases = append(aliases, venue.Alias)
+				}
+			}
+			if len(aliases) == 0 {
+				return fmt.Errorf("group @%s needs at least one venue alias", name)
+			}
+
+			groups, err := storage.LoadVenueGroups()
+			if err != nil {
+				return err
+			}
+			for existing := range groups {
+				if strings.EqualFold(existing, name) {
+					delete(groups, existing)
+				}
+			}
+			groups[name] = aliases
+			if err := storage.SaveVenueGroups(groups); err != nil {
+				return err
+			}
+
+			fmt.Printf("Saved group @%s: %s.\n", name, strings.Join(aliases, ", "))
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a venue group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimPrefix(strings.TrimSpace(args[0]), "@")
+			groups, err := storage.LoadVenueGroups()
+			if err != nil {
+				return err
+			}
+			found := false
+			for existing := range groups {
+				if strings.EqualFold(existing, name) {
+					delete(groups, existing)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("venue group %q not found", name)
+			}
+			if err := storage.SaveVenueGroups(groups); err != nil {
+				return err
+			}
+
+			fmt.Printf("Removed group @%s.\n", name)
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+func venuesRulesCmd() *cobra.Command {
+	var prefer string
+	var exclude string
+	var only string
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "rules <alias>",
+		Short: "Show or set preferred, excluded and allowed courts for a venue",
+		Long: `Show or set preferred, excluded and allowed courts for a venue.
+
+Courts are matched by name, ignoring case. A plain name matches every court
+whose name contains it, so "Binnen" covers "Binnen 1" and "Binnen 2". Use
+glob patterns (*, ?, [...]) for anything stricter, e.g. "Padel ?" or "*buiten".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			venues, err := storage.LoadVenues()
+			if err != nil {
+				return err
+			}
+			index := -1
+			for i, venue := range venues {
+				if strings.EqualFold(venue.Alias, strings.TrimSpace(args[0])) {
+					index = i
+					break
+				}
+			}
+			if index == -1 {
+				return fmt.Errorf("venue alias %q not found", args[0])
+			}
+			venue := &venues[index]
+
+			changed := cmd.Flags().Changed("prefer") || cmd.Flags().Changed("exclude") || cmd.Flags().Changed("only")
+			if clear && changed {
+				return fmt.Errorf("use either --clear or --prefer/--exclude/--only, not both")
+			}
+			if !clear && !changed {
+				if venue.CourtRules == nil {
+					fmt.Printf("No court rules for %s.\n", venue.Alias)
+					return nil
+				}
+				printCourtRules(*venue.CourtRules)
+				return nil
+			}
+
+			rules := storage.CourtRules{}
+			if venue.CourtRules != nil {
+				rules = *venue.CourtRules
+			}
+			if cmd.Flags().Changed("prefer") {
+				rules.Prefer = splitAliases(prefer)
+			}
+			if cmd.Flags().Changed("exclude") {
+				rules.Exclude = splitAliases(exclude)
+			}
+			if cmd.Flags().Changed("only") {
+				rules.Only = splitAliases(only)
+			}
+			for _, pattern := range append(append(append([]string{}, rules.Prefer...), rules.Exclude...), rules.Only...) {
+				if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+					return fmt.Errorf("invalid court pattern %q", pattern)
+				}
+			}
+
+			venue.CourtRules = &rules
+			if clear || (len(rules.Prefer) == 0 && len(rules.Exclude) == 0 && len(rules.Only) == 0) {
+				venue.CourtRules = nil
+			}
+			if err := storage.SaveVenues(venues); err != nil {
+				return err
+			}
+			if venue.CourtRules == nil {
+				fmt.Printf("Cleared court rules for %s.\n", venue.Alias)
+				return nil
+			}
+			fmt.Printf("Saved court rules for %s.\n", venue.Alias)
+			printCourtRules(*venue.CourtRules)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&prefer, "prefer", "", "Comma-separated courts or patterns, best first")
+	cmd.Flags().StringVar(&exclude, "exclude", "", "Comma-separated courts or patterns never to offer")
+	cmd.Flags().StringVar(&only, "only", "", "Comma-separated courts or patterns to limit results to")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove all court rules")
+	return cmd
+}
+
+func printCourtRules(rules storage.CourtRules) {
+	if len(rules.Prefer) > 0 {
+		fmt.Printf("Preferred courts: %s\n", strings.Join(rules.Prefer, ", "))
+	}
+	if len(rules.Exclude) > 0 {
+		fmt.Printf("Excluded courts: %s\n", strings.Join(rules.Exclude, ", "))
+	}
+	if len(rules.Only) > 0 {
+		fmt.Printf("Only courts: %s\n", strings.Join(rules.Only, ", "))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -20,19 +21,72 @@ type Booking struct {
 	VenueTimezone string  `json:"venue_timezone"`
 	Duration      int     `json:"duration"`
 	Price         float64 `json:"price"`
+	Currency      string  `json:"currency"`
 	BookedAt      string  `json:"booked_at"`
 	Source        string  `json:"source"`
+	// InProgress is set by ListBookings when the game is being played at
+	// BookingFilter.Now.
+	InProgress bool `json:"in_progress,omitempty"`
 }
 
+// DefaultBookingDuration is the length in minutes assumed for bookings
+// stored without one.
+const DefaultBookingDuration = 90
+
+// DefaultCurrency is the currency assumed for bookings stored without one.
+const DefaultCurrency = "EUR"
+
+// BookingFilter selects bookings. All set fields must match; list fields
+// match when any of their values does.
 type BookingFilter struct {
-	From     string
-	To       string
-	Past     bool
-	Upcoming bool
-	NowDate  string
-	NowTime  string
+	From  string
+	To    string
+	Dates []string
+	// Past, InProgress and Upcoming select bookings by start_utc relative to
+	// Now, an RFC 3339 UTC timestamp: past games have ended, games in
+	// progress have started but not ended, upcoming games have not started.
+	// When several are set a booking matching any of them is kept. They
+	// combine with the date fields, which narrow the result further.
+	Past       bool
+	InProgress bool
+	Upcoming   bool
+	Now        string
+
+	// Venues match the venue alias or ID exactly, or part of the venue name.
+	Venues   []string
+	Courts   []string
+	Weekdays []time.Weekday
+	Sources  []string
+	// MinPrice and MaxPrice are in PriceCurrency, DefaultCurrency when
+	// empty; bookings in other currencies never match a price limit.
+	MinPrice      float64
+	MaxPrice      float64
+	PriceCurrency string
+	// Search matches part of the venue, court or booking ID.
+	Search string
+
+	// Sort is one of BookingSorts; Desc reverses it. Limit 0 means no limit.
+	Sort  string
+	Desc  bool
+	Limit int
+}
+
+// BookingSorts are the accepted BookingFilter.Sort values.
+var BookingSorts = []string{"date", "price", "venue", "court", "duration"}
+
+var bookingSortColumns = map[string]string{
+	"":         "start_utc, date, time",
+	"date":     "start_utc, date, time",
+	"price":    "price, start_utc",
+	"venue":    "venue_name COLLATE NOCASE, start_utc",
+	"court":    "court COLLATE NOCASE, start_utc",
+	"duration": "duration, start_utc",
 }
 
+// bookingEndUTC is the SQL expression for the end of a booking in the same
+// format as start_utc.
+var bookingEndUTC = fmt.Sprintf("strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', start_utc, '+' || (CASE WHEN duration > 0 THEN duration ELSE %d END) || ' minutes')", DefaultBookingDuration)
+
 func OpenBookingsDB() (*sql.DB, error) {
 	if _, err := ensureConfigDir(); err != nil {
 		return nil, err
@@ -69,6 +123,7 @@ CREATE TABLE IF NOT EXISTS bookings (
   venue_timezone TEXT,
   duration INTEGER,
   price REAL,
+  currency TEXT,
   players TEXT,
   booked_by TEXT,
   booked_at TEXT,
@@ -83,11 +138,14 @@ CREATE TABLE IF NOT EXISTS bookings (
 		return fmt.Errorf("create bookings index: %w", err)
 	}
 
-	if err := ensureBookingsColumns(db, []string{"start_utc", "venue_timezone"}); err != nil {
+	if err := ensureBookingsColumns(db, []string{"start_utc", "venue_timezone", "currency"}); err != nil {
 		return err
 	}
 
-	return nil
+	if err := ensurePlayersSchema(db); err != nil {
+		return err
+	}
+	return ensureLevelsSchema(db)
 }
 
 func ensureBookingsColumns(db *sql.DB, columns []string) error {
@@ -129,8 +187,8 @@ func ensureBookingsColumns(db *sql.DB, columns []string) error {
 func AddBooking(db *sql.DB, booking Booking) error {
 	query := `
 INSERT INTO bookings (
-  id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, booked_at, source
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+  id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, currency, booked_at, source
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	_, err := db.Exec(
 		query,
@@ -145,6 +203,7 @@ INSERT INTO bookings (
 		booking.VenueTimezone,
 		booking.Duration,
 		booking.Price,
+		booking.Currency,
 		booking.BookedAt,
 		booking.Source,
 	)
@@ -154,8 +213,8 @@ INSERT INTO bookings (
 func AddBookingIfNotExists(db *sql.DB, booking Booking) (bool, error) {
 	query := `
 INSERT OR IGNORE INTO bookings (
-  id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, booked_at, source
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+  id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, currency, booked_at, source
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	res, err := db.Exec(
 		query,
@@ -170,6 +229,7 @@ INSERT OR IGNORE INTO bookings (
 		booking.VenueTimezone,
 		booking.Duration,
 		booking.Price,
+		booking.Currency,
 		booking.BookedAt,
 		booking.Source,
 	)
@@ -183,6 +243,47 @@ INSERT OR IGNORE INTO bookings (
 	return affected > 0, nil
 }
 
+// AddBookings inserts bookings in a single transaction, so an import either
+// lands completely or not at all.
+func AddBookings(db *sql.DB, bookings []Booking) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`
+INSERT INTO bookings (
+  id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, currency, booked_at, source
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, booking := range bookings {
+		if _, err := stmt.Exec(
+			booking.ID,
+			booking.VenueAlias,
+			booking.VenueName,
+			booking.VenueID,
+			booking.Court,
+			booking.Date,
+			booking.Time,
+			booking.StartUTC,
+			booking.VenueTimezone,
+			booking.Duration,
+			booking.Price,
+			booking.Currency,
+			booking.BookedAt,
+			booking.Source,
+		); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("insert booking %s: %w", booking.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
 func RemoveBooking(db *sql.DB, id string) (bool, error) {
 	res, err := db.Exec("DELETE FROM bookings WHERE id = ?", id)
 	if err != nil {
@@ -197,36 +298,27 @@ func RemoveBooking(db *sql.DB, id string) (bool, error) {
 
 func ListBookings(db *sql.DB, filter BookingFilter) ([]Booking, error) {
 	base := `
-SELECT id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, booked_at, source
+SELECT id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, currency, booked_at, source
 FROM bookings`
 
-	conds := []string{}
-	args := []any{}
-
-	if filter.From != "" {
-		conds = append(conds, "date >= ?")
-		args = append(args, filter.From)
-	}
-	if filter.To != "" {
-		conds = append(conds, "date <= ?")
-		args = append(args, filter.To)
+	orderBy, ok := bookingSortColumns[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q (expected %s)", filter.Sort, strings.Join(BookingSorts, ", "))
 	}
-	if filter.From == "" && filter.To == "" {
-		if filter.Past {
-			conds = append(conds, "date <= ?")
-			args = append(args, filter.NowDate)
-		}
-		if filter.Upcoming {
-			conds = append(conds, "date >= ?")
-			args = append(args, filter.NowDate)
-		}
+	if filter.Desc {
+		orderBy = descending(orderBy)
 	}
 
+	conds, args := bookingConditions(filter)
 	query := base
 	if len(conds) > 0 {
 		query += " WHERE " + strings.Join(conds, " AND ")
 	}
-	query += " ORDER BY date, time"
+	query += " ORDER BY " + orderBy
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -234,12 +326,83 @@ FROM bookings`
 	}
 	defer rows.Close()
 
+	bookings, err := scanBookings(rows)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Now != "" {
+		now, err := time.Parse(time.RFC3339, filter.Now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid now %q: %w", filter.Now, err)
+		}
+		for i := range bookings {
+			bookings[i].InProgress = bookingInProgress(bookings[i], now)
+		}
+	}
+	return bookings, nil
+}
+
+// BackfillStartUTC fills in start_utc for bookings stored without one.
+// resolve sets StartUTC from the local date and time, and may correct the
+// venue timezone, date and time; bookings it leaves without a StartUTC are
+// skipped. It returns the number of bookings updated.
+func BackfillStartUTC(db *sql.DB, resolve func(*Booking)) (int, error) {
+	rows, err := db.Query(`
+SELECT id, venue_alias, venue_name, venue_id, court, date, time, start_utc, venue_timezone, duration, price, currency, booked_at, source
+FROM bookings
+WHERE COALESCE(start_utc, '') = ''`)
+	if err != nil {
+		return 0, err
+	}
+	bookings, err := scanBookings(rows)
+	rows.Close()
+	if err != nil {
+		return 0, err
+	}
+	if len(bookings) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for i := range bookings {
+		booking := &bookings[i]
+		resolve(booking)
+		if booking.StartUTC == "" {
+			continue
+		}
+		_, err := tx.Exec(
+			"UPDATE bookings SET start_utc = ?, venue_timezone = ?, date = ?, time = ? WHERE id = ?",
+			booking.StartUTC,
+			booking.VenueTimezone,
+			booking.Date,
+			booking.Time,
+			booking.ID,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("backfill booking %s: %w", booking.ID, err)
+		}
+		updated++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+func scanBookings(rows *sql.Rows) ([]Booking, error) {
 	bookings := []Booking{}
 	for rows.Next() {
 		var booking Booking
 		var startUTC sql.NullString
 		var venueTZ sql.NullString
+		var duration sql.NullInt64
 		var price sql.NullFloat64
+		var currency sql.NullString
 		if err := rows.Scan(
 			&booking.ID,
 			&booking.VenueAlias,
@@ -250,58 +413,143 @@ FROM bookings`
 			&booking.Time,
 			&startUTC,
 			&venueTZ,
-			&booking.Duration,
+			&duration,
 			&price,
+			&currency,
 			&booking.BookedAt,
 			&booking.Source,
 		); err != nil {
 			return nil, err
 		}
-		if startUTC.Valid {
-			booking.StartUTC = startUTC.String
-		}
-		if venueTZ.Valid {
-			booking.VenueTimezone = venueTZ.String
-		}
-		if price.Valid {
-			booking.Price = price.Float64
-		}
+		booking.StartUTC = startUTC.String
+		booking.VenueTimezone = venueTZ.String
+		booking.Duration = int(duration.Int64)
+		booking.Price = price.Float64
+		booking.Currency = currency.String
 		bookings = append(bookings, booking)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	return bookings, nil
+}
 
-	if filter.From == "" && filter.To == "" {
-		if filter.Past || filter.Upcoming {
-			return filterByTime(bookings, filter), nil
-		}
+func bookingInProgress(booking Booking, now time.Time) bool {
+	start, err := time.Parse(time.RFC3339, booking.StartUTC)
+	if err != nil {
+		return false
 	}
-	return bookings, nil
+	duration := booking.Duration
+	if duration <= 0 {
+		duration = DefaultBookingDuration
+	}
+	end := start.Add(time.Duration(duration) * time.Minute)
+	return !now.Before(start) && now.Before(end)
 }
 
-func filterByTime(bookings []Booking, filter BookingFilter) []Booking {
-	filtered := make([]Booking, 0, len(bookings))
-	for _, booking := range bookings {
-		if booking.Date != filter.NowDate {
-			filtered = append(filtered, booking)
-			continue
+func bookingConditions(filter BookingFilter) ([]string, []any) {
+	conds := []string{}
+	args := []any{}
+
+	if filter.From != "" {
+		conds = append(conds, "date >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		conds = append(conds, "date <= ?")
+		args = append(args, filter.To)
+	}
+	if len(filter.Dates) > 0 {
+		conds = append(conds, "date IN ("+placeholders(len(filter.Dates))+")")
+		for _, date := range filter.Dates {
+			args = append(args, date)
 		}
-		if filter.NowTime == "" {
-			filtered = append(filtered, booking)
-			continue
+	}
+	states := []string{}
+	if filter.Past {
+		states = append(states, bookingEndUTC+" <= ?")
+		args = append(args, filter.Now)
+	}
+	if filter.InProgress {
+		states = append(states, "(start_utc <= ? AND "+bookingEndUTC+" > ?)")
+		args = append(args, filter.Now, filter.Now)
+	}
+	if filter.Upcoming {
+		states = append(states, "start_utc > ?")
+		args = append(args, filter.Now)
+	}
+	if len(states) > 0 {
+		conds = append(conds, "COALESCE(start_utc, '') != ''", "("+strings.Join(states, " OR ")+")")
+	}
+
+	if len(filter.Venues) > 0 {
+		venueConds := []string{}
+		for _, venue := range filter.Venues {
+			venueConds = append(venueConds, "venue_alias = ? COLLATE NOCASE", "venue_id = ?", `venue_name LIKE ? ESCAPE '\'`)
+			args = append(args, venue, venue, containsPattern(venue))
 		}
-		if filter.Past {
-			if booking.Time < filter.NowTime {
-				filtered = append(filtered, booking)
-			}
-			continue
+		conds = append(conds, "("+strings.Join(venueConds, " OR ")+")")
+	}
+	if len(filter.Courts) > 0 {
+		conds = append(conds, "court COLLATE NOCASE IN ("+placeholders(len(filter.Courts))+")")
+		for _, court := range filter.Courts {
+			args = append(args, court)
 		}
-		if filter.Upcoming {
-			if booking.Time >= filter.NowTime {
-				filtered = append(filtered, booking)
-			}
+	}
+	if len(filter.Weekdays) > 0 {
+		// strftime('%w') numbers days like time.Weekday, from Sunday = 0.
+		conds = append(conds, "CAST(strftime('%w', date) AS INTEGER) IN ("+placeholders(len(filter.Weekdays))+")")
+		for _, weekday := range filter.Weekdays {
+			args = append(args, int(weekday))
 		}
 	}
-	return filtered
+	if len(filter.Sources) > 0 {
+		conds = append(conds, "source IN ("+placeholders(len(filter.Sources))+")")
+		for _, source := range filter.Sources {
+			args = append(args, source)
+		}
+	}
+	if filter.MinPrice > 0 || filter.MaxPrice > 0 {
+		// Prices only compare within one currency.
+		currency := strings.ToUpper(filter.PriceCurrency)
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		conds = append(conds, "UPPER(COALESCE(NULLIF(currency, ''), ?)) = ?")
+		args = append(args, DefaultCurrency, currency)
+	}
+	if filter.MinPrice > 0 {
+		conds = append(conds, "price >= ?")
+		args = append(args, filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		conds = append(conds, "price <= ?")
+		args = append(args, filter.MaxPrice)
+	}
+	if filter.Search != "" {
+		pattern := containsPattern(filter.Search)
+		conds = append(conds, `(venue_name LIKE ? ESCAPE '\' OR venue_alias LIKE ? ESCAPE '\' OR court LIKE ? ESCAPE '\' OR id LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+	return conds, args
+}
+
+// containsPattern is a LIKE pattern matching text anywhere, with the LIKE
+// wildcards in text taken literally. It goes with ESCAPE '\'.
+func containsPattern(text string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return "%" + escaper.Replace(text) + "%"
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// descending reverses every column of an ORDER BY list.
+func descending(orderBy string) string {
+	columns := strings.Split(orderBy, ", ")
+	for i, column := range columns {
+		columns[i] = column + " DESC"
+	}
+	return strings.Join(columns, ", ")
 }
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListBookingsPriceAndSearch(t *testing.T) {
+	db := openTestDB(t)
+	bookings := []Booking{
+		{ID: "eur-30", VenueName: "Blijdorp", Court: "Court 1", Date: "2026-10-01", StartUTC: "2026-10-01T10:00:00Z", Price: 30, Currency: "EUR"},
+		{ID: "eur-45", VenueName: "Blijdorp", Court: "Court_2", Date: "2026-10-02", StartUTC: "2026-10-02T10:00:00Z", Price: 45, Currency: "EUR"},
+		{ID: "unset-40", VenueName: "100% Padel", Court: "Court 3", Date: "2026-10-03", StartUTC: "2026-10-03T10:00:00Z", Price: 40},
+		{ID: "sek-400", VenueName: "PDL Stockholm", Court: "Bana 1", Date: "2026-10-04", StartUTC: "2026-10-04T10:00:00Z", Price: 400, Currency: "sek"},
+		{ID: "sek-35", VenueName: "PDL Stockholm", Court: "Bana 2", Date: "2026-10-05", StartUTC: "2026-10-05T10:00:00Z", Price: 35, Currency: "SEK"},
+	}
+	if err := AddBookings(db, bookings); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter BookingFilter
+		want   []string
+	}{
+		// Bookings without a currency count as the default currency.
+		{name: "max price in default currency", filter: BookingFilter{MaxPrice: 40}, want: []string{"eur-30", "unset-40"}},
+		{name: "min price in euros", filter: BookingFilter{MinPrice: 35, PriceCurrency: "EUR"}, want: []string{"eur-45", "unset-40"}},
+		{name: "price range in kronor", filter: BookingFilter{MinPrice: 30, MaxPrice: 500, PriceCurrency: "SEK"}, want: []string{"sek-400", "sek-35"}},
+		{name: "search court", filter: BookingFilter{Search: "bana"}, want: []string{"sek-400", "sek-35"}},
+		{name: "search id", filter: BookingFilter{Search: "eur-4"}, want: []string{"eur-45"}},
+		// LIKE wildcards in the search text are matched literally.
+		{name: "search underscore", filter: BookingFilter{Search: "t_2"}, want: []string{"eur-45"}},
+		{name: "search percent", filter: BookingFilter{Search: "100%"}, want: []string{"unset-40"}},
+		{name: "search percent alone", filter: BookingFilter{Search: "%"}, want: []string{"unset-40"}},
+		{name: "venue name with percent", filter: BookingFilter{Venues: []string{"0% pad"}}, want: []string{"unset-40"}},
+		{name: "venue name underscore", filter: BookingFilter{Venues: []string{"b_ijdorp"}}, want: []string{}},
+	}
+	for _, tt := range tests {
+		listed, err := ListBookings(db, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := []string{}
+		for _, booking := range listed {
+			got = append(got, booking.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ListBookings = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestListBookingsTimeStates(t *testing.T) {
+	db := openTestDB(t)
+	bookings := []Booking{
+		{ID: "ended", Date: "2026-10-24", StartUTC: "2026-10-24T08:00:00Z", Duration: 60},
+		{ID: "ends-now", Date: "2026-10-24", StartUTC: "2026-10-24T08:30:00Z", Duration: 90},
+		{ID: "default-duration", Date: "2026-10-24", StartUTC: "2026-10-24T09:00:00Z"},
+		{ID: "starts-now", Date: "2026-10-24", StartUTC: "2026-10-24T10:00:00Z", Duration: 60},
+		{ID: "later", Date: "2026-10-24", StartUTC: "2026-10-24T12:00:00Z", Duration: 60},
+		{ID: "next-week", Date: "2026-10-31", StartUTC: "2026-10-31T10:00:00Z", Duration: 60},
+		{ID: "no-start", Date: "2026-10-20"},
+	}
+	if err := AddBookings(db, bookings); err != nil {
+		t.Fatal(err)
+	}
+
+	const now = "2026-10-24T10:00:00Z"
+	tests := []struct {
+		name   string
+		filter BookingFilter
+		want   []string
+	}{
+		{name: "no filter", filter: BookingFilter{}, want: []string{"no-start", "ended", "ends-now", "default-duration", "starts-now", "later", "next-week"}},
+		{name: "past", filter: BookingFilter{Past: true, Now: now}, want: []string{"ended", "ends-now"}},
+		{name: "in progress", filter: BookingFilter{InProgress: true, Now: now}, want: []string{"default-duration", "starts-now"}},
+		{name: "upcoming", filter: BookingFilter{Upcoming: true, Now: now}, want: []string{"later", "next-week"}},
+		{name: "in progress or upcoming", filter: BookingFilter{InProgress: true, Upcoming: true, Now: now}, want: []string{"default-duration", "starts-now", "later", "next-week"}},
+		{name: "past within dates", filter: BookingFilter{From: "2026-10-20", To: "2026-10-31", Past: true, Now: now}, want: []string{"ended", "ends-now"}},
+		{name: "dates only", filter: BookingFilter{Dates: []string{"2026-10-20", "2026-10-31"}}, want: []string{"no-start", "next-week"}},
+	}
+	for _, tt := range tests {
+		listed, err := ListBookings(db, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := []string{}
+		for _, booking := range listed {
+			got = append(got, booking.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ListBookings = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	listed, err := ListBookings(db, BookingFilter{InProgress: true, Upcoming: true, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, booking := range listed {
+		wantInProgress := booking.ID == "default-duration" || booking.ID == "starts-now"
+		if booking.InProgress != wantInProgress {
+			t.Errorf("%s: InProgress = %v, want %v", booking.ID, booking.InProgress, wantInProgress)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const geocodeFile = "geocode.json"
+
+// GeocodeEntry is a cached geocoding result.
+type GeocodeEntry struct {
+	Lat       float64 `json:"lat"`
+	Lon       float64 `json:"lon"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+func GeocodePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, geocodeFile), nil
+}
+
+// GeocodeKey normalises a query so that "Rotterdam " and "rotterdam" share a
+// cache entry.
+func GeocodeKey(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+func LoadGeocodeCache() (map[string]GeocodeEntry, error) {
+	path, err := GeocodePath()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]GeocodeEntry{}, nil
+		}
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("geocode cache path is a directory: %s", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	cache := map[string]GeocodeEntry{}
+	if err := json.NewDecoder(file).Decode(&cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+func SaveGeocodeCache(cache map[string]GeocodeEntry) error {
+	if _, err := ensureConfigDir(); err != nil {
+		return err
+	}
+
+	path, err := GeocodePath()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(cache)
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// LevelSnapshot is a player's level at one point in time.
+type LevelSnapshot struct {
+	UserID          string  `json:"user_id"`
+	Name            string  `json:"name"`
+	LevelValue      float64 `json:"level_value"`
+	LevelConfidence float64 `json:"level_confidence"`
+	Source          string  `json:"source"`
+	RecordedAt      string  `json:"recorded_at"`
+}
+
+func ensureLevelsSchema(db *sql.DB) error {
+	createTable := `
+CREATE TABLE IF NOT EXISTS level_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id TEXT NOT NULL,
+  name TEXT,
+  level_value REAL,
+  level_confidence REAL,
+  source TEXT,
+  recorded_at TEXT
+);`
+
+	if _, err := db.Exec(createTable); err != nil {
+		return fmt.Errorf("create level_history table: %w", err)
+	}
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS idx_level_history_user ON level_history(user_id, recorded_at);"); err != nil {
+		return fmt.Errorf("create level_history index: %w", err)
+	}
+	return nil
+}
+
+// AddLevelSnapshot records a level. A snapshot identical to the latest one
+// recorded on the same day is not stored again, so frequent syncs do not
+// flood the history. It reports whether a row was added.
+func AddLevelSnapshot(db *sql.DB, snapshot LevelSnapshot) (bool, error) {
+	var value, confidence sql.NullFloat64
+	var recordedAt sql.NullString
+	err := db.QueryRow(`
+SELECT level_value, level_confidence, recorded_at
+FROM level_history
+WHERE user_id = ?
+ORDER BY recorded_at DESC, id DESC
+LIMIT 1`, snapshot.UserID).Scan(&value, &confidence, &recordedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	if err == nil &&
+		value.Float64 == snapshot.LevelValue &&
+		confidence.Float64 == snapshot.LevelConfidence &&
+		len(recordedAt.String) >= 10 && strings.HasPrefix(snapshot.RecordedAt, recordedAt.String[:10]) {
+		return false, nil
+	}
+
+	_, err = db.Exec(`
+INSERT INTO level_history (user_id, name, level_value, level_confidence, source, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?);`,
+		snapshot.UserID,
+		snapshot.Name,
+		snapshot.LevelValue,
+		snapshot.LevelConfidence,
+		snapshot.Source,
+		snapshot.RecordedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func ListLevelHistory(db *sql.DB, userID string) ([]LevelSnapshot, error) {
+	rows, err := db.Query(`
+SELECT user_id, name, level_value, level_confidence, source, recorded_at
+FROM level_history
+WHERE user_id = ?
+ORDER BY recorded_at, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []LevelSnapshot{}
+	for rows.Next() {
+		var snapshot LevelSnapshot
+		var name, source, recordedAt sql.NullString
+		var value, confidence sql.NullFloat64
+		if err := rows.Scan(&snapshot.UserID, &name, &value, &confidence, &source, &recordedAt); err != nil {
+			return nil, err
+		}
+		snapshot.Name = name.String
+		snapshot.LevelValue = value.Float64
+		snapshot.LevelConfidence = confidence.Float64
+		snapshot.Source = source.String
+		snapshot.RecordedAt = recordedAt.String
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
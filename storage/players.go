@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MatchPlayer is one player in a synced match, with the level Playtomic
+// reported for them at sync time.
+type MatchPlayer struct {
+	MatchID         string  `json:"match_id"`
+	UserID          string  `json:"user_id"`
+	Name            string  `json:"name"`
+	TeamID          string  `json:"team_id"`
+	LevelValue      float64 `json:"level_value"`
+	LevelConfidence float64 `json:"level_confidence"`
+	Organizer       bool    `json:"organizer"`
+	SyncedAt        string  `json:"synced_at"`
+}
+
+func ensurePlayersSchema(db *sql.DB) error {
+	createTable := `
+CREATE TABLE IF NOT EXISTS match_players (
+  match_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  name TEXT,
+  team_id TEXT,
+  level_value REAL,
+  level_confidence REAL,
+  organizer INTEGER,
+  synced_at TEXT,
+  PRIMARY KEY (match_id, user_id)
+);`
+
+	if _, err := db.Exec(createTable); err != nil {
+		return fmt.Errorf("create match_players table: %w", err)
+	}
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS idx_match_players_user ON match_players(user_id);"); err != nil {
+		return fmt.Errorf("create match_players index: %w", err)
+	}
+	return nil
+}
+
+// SaveMatchPlayers replaces the stored roster of a match.
+func SaveMatchPlayers(db *sql.DB, matchID string, players []MatchPlayer) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM match_players WHERE match_id = ?", matchID); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	for _, player := range players {
+		organizer := 0
+		if player.Organizer {
+			organizer = 1
+		}
+		_, err := tx.Exec(`
+INSERT INTO match_players (match_id, user_id, name, team_id, level_value, level_confidence, organizer, synced_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+			matchID,
+			player.UserID,
+			player.Name,
+			player.TeamID,
+			player.LevelValue,
+			player.LevelConfidence,
+			organizer,
+			player.SyncedAt,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("save player %s of match %s: %w", player.UserID, matchID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RosterSyncTimes returns when the roster of each synced match was last
+// fetched, by match ID.
+func RosterSyncTimes(db *sql.DB) (map[string]string, error) {
+	rows, err := db.Query("SELECT match_id, MAX(COALESCE(synced_at, '')) FROM match_players GROUP BY match_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	synced := map[string]string{}
+	for rows.Next() {
+		var id, syncedAt string
+		if err := rows.Scan(&id, &syncedAt); err != nil {
+			return nil, err
+		}
+		synced[id] = syncedAt
+	}
+	return synced, rows.Err()
+}
+
+func ListMatchPlayers(db *sql.DB) ([]MatchPlayer, error) {
+	rows, err := db.Query(`
+SELECT match_id, user_id, name, team_id, level_value, level_confidence, organizer, synced_at
+FROM match_players
+ORDER BY match_id, team_id, name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMatchPlayers(rows)
+}
+
+// LatestPlayerLevel returns the player's entry in the roster of their most
+// recent match that reported a level. Matches are ordered by start time, as
+// every roster from one sync shares the same synced_at.
+func LatestPlayerLevel(db *sql.DB, userID string) (MatchPlayer, bool, error) {
+	rows, err := db.Query(`
+SELECT mp.match_id, mp.user_id, mp.name, mp.team_id, mp.level_value, mp.level_confidence, mp.organizer, mp.synced_at
+FROM match_players mp
+JOIN bookings b ON b.id = mp.match_id
+WHERE mp.user_id = ? AND mp.level_value > 0
+ORDER BY b.start_utc DESC, b.date DESC, b.time DESC, mp.synced_at DESC
+LIMIT 1`, userID)
+	if err != nil {
+		return MatchPlayer{}, false, err
+	}
+	defer rows.Close()
+
+	players, err := scanMatchPlayers(rows)
+	if err != nil || len(players) == 0 {
+		return MatchPlayer{}, false, err
+	}
+	return players[0], true, nil
+}
+
+func scanMatchPlayers(rows *sql.Rows) ([]MatchPlayer, error) {
+	players := []MatchPlayer{}
+	for rows.Next() {
+		var player MatchPlayer
+		var name, teamID, syncedAt sql.NullString
+		var level, confidence sql.NullFloat64
+		var organizer sql.NullInt64
+		if err := rows.Scan(&player.MatchID, &player.UserID, &name, &teamID, &level, &confidence, &organizer, &syncedAt); err != nil {
+			return nil, err
+		}
+		player.Name = name.String
+		player.TeamID = teamID.String
+		player.LevelValue = level.Float64
+		player.LevelConfidence = confidence.Float64
+		player.Organizer = organizer.Int64 == 1
+		player.SyncedAt = syncedAt.String
+		players = append(players, player)
+	}
+	return players, rows.Err()
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "bookings.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := ensureBookingsSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestLatestPlayerLevelOrdersByMatchStart(t *testing.T) {
+	db := openTestDB(t)
+	// One sync stores every roster with the same synced_at, listed in no
+	// particular order.
+	const syncedAt = "2026-10-18T09:00:00Z"
+	matches := []struct {
+		id       string
+		startUTC string
+		level    float64
+	}{
+		{id: "m2", startUTC: "2026-10-10T18:00:00Z", level: 3.4},
+		{id: "m3", startUTC: "2026-10-15T18:00:00Z", level: 0},
+		{id: "m1", startUTC: "2026-09-01T18:00:00Z", level: 3.1},
+	}
+	for _, match := range matches {
+		if err := AddBooking(db, Booking{ID: match.id, Date: match.startUTC[:10], StartUTC: match.startUTC}); err != nil {
+			t.Fatal(err)
+		}
+		players := []MatchPlayer{
+			{UserID: "me", Name: "Me", LevelValue: match.level, SyncedAt: syncedAt},
+			{UserID: "other", Name: "Other", LevelValue: 5, SyncedAt: syncedAt},
+		}
+		if err := SaveMatchPlayers(db, match.id, players); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	player, found, err := LatestPlayerLevel(db, "me")
+	if err != nil || !found {
+		t.Fatalf("LatestPlayerLevel = %v, %v", found, err)
+	}
+	// m3 is later but has no level, so m2 is the latest one that counts.
+	if player.MatchID != "m2" || player.LevelValue != 3.4 {
+		t.Errorf("LatestPlayerLevel = %s %.1f, want m2 3.4", player.MatchID, player.LevelValue)
+	}
+
+	if _, found, err := LatestPlayerLevel(db, "nobody"); err != nil || found {
+		t.Errorf("LatestPlayerLevel(nobody) = %v, %v, want not found", found, err)
+	}
+}
@@ -9,55 +9,144 @@ import (
 )
 
 type Venue struct {
-	ID       string `json:"id"`
-	Alias    string `json:"alias"`
-	Name     string `json:"name"`
-	Indoor   bool   `json:"indoor"`
-	TimeZone string `json:"timezone"`
+	ID            string       `json:"id"`
+	Alias         string       `json:"alias"`
+	Name          string       `json:"name"`
+	Indoor        bool         `json:"indoor"`
+	TimeZone      string       `json:"timezone"`
+	Lat           float64      `json:"lat,omitempty"`
+	Lon           float64      `json:"lon,omitempty"`
+	IndoorCourts  int          `json:"indoor_courts,omitempty"`
+	OutdoorCourts int          `json:"outdoor_courts,omitempty"`
+	Address       string       `json:"address,omitempty"`
+	Courts        []VenueCourt `json:"courts,omitempty"`
+	RefreshedAt   string       `json:"refreshed_at,omitempty"`
+	NotFound      bool         `json:"not_found,omitempty"`
+	CourtRules    *CourtRules  `json:"court_rules,omitempty"`
+	Policy        *VenuePolicy `json:"policy,omitempty"`
 }
 
+// VenuePolicy caches when a club is open and how far ahead it can be booked.
+// OpeningHours is keyed by upper-case weekday ("MONDAY"); zero values mean
+// the club does not publish that detail.
+type VenuePolicy struct {
+	OpeningHours              map[string]OpeningHours `json:"opening_hours,omitempty"`
+	MaxAdvanceDays            int                     `json:"max_advance_days,omitempty"`
+	BookingOpensAt            string                  `json:"booking_opens_at,omitempty"`
+	CancellationWindowMinutes int                     `json:"cancellation_window_minutes,omitempty"`
+}
+
+type OpeningHours struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// CourtRules narrow down and order the courts of a venue. Entries are court
+// names or glob patterns such as "Padel *" or "*outdoor*", matched without
+// regard to case. Prefer lists courts best first; courts matching Exclude, or
+// not matching Only when it is set, are never offered.
+type CourtRules struct {
+	Prefer  []string `json:"prefer,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	Only    []string `json:"only,omitempty"`
+}
+
+// VenueCourt is a court as last seen in the tenant's resource list.
+type VenueCourt struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Size    string `json:"size,omitempty"`
+	Feature string `json:"feature,omitempty"`
+}
+
+// VenuesFile is the layout of venues.json. Groups map a group name to venue
+// aliases in priority order.
 type VenuesFile struct {
-	Venues []Venue `json:"venues"`
+	Venues []Venue             `json:"venues"`
+	Groups map[string][]string `json:"groups,omitempty"`
 }
 
 const DefaultVenueTimezone = "Europe/Madrid"
 
 func LoadVenues() ([]Venue, error) {
-	path, err := VenuesPath()
+	payload, err := loadVenuesFile()
+	if err != nil {
+		return nil, err
+	}
+	return payload.Venues, nil
+}
+
+func LoadVenueGroups() (map[string][]string, error) {
+	payload, err := loadVenuesFile()
 	if err != nil {
 		return nil, err
 	}
+	return payload.Groups, nil
+}
+
+func loadVenuesFile() (VenuesFile, error) {
+	path, err := VenuesPath()
+	if err != nil {
+		return VenuesFile{}, err
+	}
 
 	info, err := os.Stat(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []Venue{}, nil
+			return VenuesFile{Venues: []Venue{}, Groups: map[string][]string{}}, nil
 		}
-		return nil, err
+		return VenuesFile{}, err
 	}
 	if info.IsDir() {
-		return nil, fmt.Errorf("venues path is a directory: %s", path)
+		return VenuesFile{}, fmt.Errorf("venues path is a directory: %s", path)
 	}
 
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return VenuesFile{}, err
 	}
 	defer file.Close()
 
 	var payload VenuesFile
 	if err := json.NewDecoder(file).Decode(&payload); err != nil {
-		return nil, err
+		return VenuesFile{}, err
+	}
+	if payload.Venues == nil {
+		payload.Venues = []Venue{}
+	}
+	if payload.Groups == nil {
+		payload.Groups = map[string][]string{}
 	}
 	for i := range payload.Venues {
 		if payload.Venues[i].TimeZone == "" {
 			payload.Venues[i].TimeZone = DefaultVenueTimezone
 		}
 	}
-	return payload.Venues, nil
+	return payload, nil
 }
 
+// SaveVenues replaces the saved venues and keeps the groups as they are.
 func SaveVenues(venues []Venue) error {
+	payload, err := loadVenuesFile()
+	if err != nil {
+		return err
+	}
+	payload.Venues = venues
+	return saveVenuesFile(payload)
+}
+
+// SaveVenueGroups replaces the venue groups and keeps the venues as they are.
+func SaveVenueGroups(groups map[string][]string) error {
+	payload, err := loadVenuesFile()
+	if err != nil {
+		return err
+	}
+	payload.Groups = groups
+	return saveVenuesFile(payload)
+}
+
+func saveVenuesFile(payload VenuesFile) error {
 	if _, err := ensureConfigDir(); err != nil {
 		return err
 	}
@@ -67,8 +156,8 @@ func SaveVenues(venues []Venue) error {
 		return err
 	}
 
-	sorted := make([]Venue, len(venues))
-	copy(sorted, venues)
+	sorted := make([]Venue, len(payload.Venues))
+	copy(sorted, payload.Venues)
 	sort.Slice(sorted, func(i, j int) bool {
 		return strings.ToLower(sorted[i].Alias) < strings.ToLower(sorted[j].Alias)
 	})
@@ -77,6 +166,7 @@ func SaveVenues(venues []Venue) error {
 			sorted[i].TimeZone = DefaultVenueTimezone
 		}
 	}
+	payload.Venues = sorted
 
 	file, err := os.Create(path)
 	if err != nil {
@@ -86,7 +176,7 @@ func SaveVenues(venues []Venue) error {
 
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(VenuesFile{Venues: sorted})
+	return encoder.Encode(payload)
 }
 
 func FindVenueByAlias(venues []Venue, alias string) (Venue, bool) {
@@ -98,3 +188,14 @@ func FindVenueByAlias(venues []Venue, alias string) (Venue, bool) {
 	}
 	return Venue{}, false
 }
+
+// FindVenueGroup looks up a group by name, ignoring case.
+func FindVenueGroup(groups map[string][]string, name string) ([]string, bool) {
+	needle := strings.ToLower(strings.TrimSpace(name))
+	for groupName, aliases := range groups {
+		if strings.ToLower(groupName) == needle {
+			return aliases, true
+		}
+	}
+	return nil, false
+}